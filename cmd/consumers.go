@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/internal/storage"
+)
+
+const consumersUsage = `usage:
+  agent consumers list
+  agent consumers lag   [-consumer NAME]
+  agent consumers reset -consumer NAME [-stream domains|social_links] [-skip]
+  agent consumers seek  -consumer NAME -stream domains|social_links (-time RFC3339 | -id N)
+
+reset переводит курсор в начало таблицы, и при следующем запуске агент разошлёт все строки заново;
+с -skip курсор стирается, и потребитель ведёт себя как новый: молча догоняет таблицу и шлёт только новые строки.
+seek ставит курсор на момент времени или строку, всё после неё будет разослано.
+reset и seek меняют сохранённый курсор; потребитель должен быть остановлен,
+иначе он перезапишет курсор своим состоянием из памяти`
+
+// runConsumersCmd обрабатывает подкоманды "agent consumers ..."
+func runConsumersCmd(ctx context.Context, client *ent.Client, store storage.CursorStore, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n%s", consumersUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return consumersList(ctx, store)
+	case "lag":
+		return consumersLag(ctx, client, store, args[1:])
+	case "reset":
+		return consumersReset(ctx, store, args[1:])
+	case "seek":
+		return consumersSeek(ctx, client, store, args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand %q\n%s", args[0], consumersUsage)
+	}
+}
+
+func consumersList(ctx context.Context, store storage.CursorStore) error {
+	states, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONSUMER\tSTREAM\tLAST_CREATED_AT\tLAST_ID\tUPDATED_AT")
+	for _, st := range states {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			st.Consumer, st.Stream, formatTime(st.Cursor.LastCreatedAt), st.Cursor.LastID, formatTime(st.UpdatedAt))
+	}
+	return w.Flush()
+}
+
+func consumersLag(ctx context.Context, client *ent.Client, store storage.CursorStore, args []string) error {
+	fs := flag.NewFlagSet("lag", flag.ContinueOnError)
+	consumer := fs.String("consumer", "", "show lag only for this consumer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	states, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CONSUMER\tSTREAM\tPENDING\tBEHIND")
+	for _, st := range states {
+		if *consumer != "" && st.Consumer != *consumer {
+			continue
+		}
+		pending, err := storage.CountPending(ctx, client, st.Stream, st.Cursor)
+		if err != nil {
+			return fmt.Errorf("count pending for %s/%s: %w", st.Consumer, st.Stream, err)
+		}
+		// отставание по времени считаем от последней обработанной строки
+		behind := "-"
+		if pending > 0 && !st.Cursor.LastCreatedAt.IsZero() {
+			behind = time.Since(st.Cursor.LastCreatedAt).Truncate(time.Second).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", st.Consumer, st.Stream, pending, behind)
+	}
+	return w.Flush()
+}
+
+func consumersReset(ctx context.Context, store storage.CursorStore, args []string) error {
+	fs := flag.NewFlagSet("reset", flag.ContinueOnError)
+	consumer := fs.String("consumer", "", "consumer name")
+	stream := fs.String("stream", "", "reset only this stream (default: all)")
+	skip := fs.Bool("skip", false, "forget the cursor instead of replaying: the consumer starts like a new one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *consumer == "" {
+		return fmt.Errorf("-consumer is required")
+	}
+
+	streams := []string{storage.StreamDomains, storage.StreamLinks}
+	if *stream != "" {
+		if err := checkStream(*stream); err != nil {
+			return err
+		}
+		streams = []string{*stream}
+	}
+
+	cur, msg := storage.ReplayCursor(), "reset to the beginning, all rows will be replayed"
+	if *skip {
+		cur, msg = storage.Cursor{}, "reset as a new consumer, existing rows will be skipped"
+	}
+	for _, s := range streams {
+		if err := store.Save(ctx, *consumer, s, cur); err != nil {
+			return fmt.Errorf("reset %s/%s: %w", *consumer, s, err)
+		}
+		fmt.Printf("%s/%s %s\n", *consumer, s, msg)
+	}
+	return nil
+}
+
+func consumersSeek(ctx context.Context, client *ent.Client, store storage.CursorStore, args []string) error {
+	fs := flag.NewFlagSet("seek", flag.ContinueOnError)
+	consumer := fs.String("consumer", "", "consumer name")
+	stream := fs.String("stream", "", "domains or social_links")
+	at := fs.String("time", "", "process rows created at or after this RFC3339 time")
+	id := fs.String("id", "", "process rows after the row with this ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *consumer == "" {
+		return fmt.Errorf("-consumer is required")
+	}
+	if err := checkStream(*stream); err != nil {
+		return err
+	}
+	if (*at == "") == (*id == "") {
+		return fmt.Errorf("exactly one of -time or -id is required")
+	}
+
+	var cur storage.Cursor
+	if *at != "" {
+		t, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			return fmt.Errorf("invalid -time: %w", err)
+		}
+		// LastID = 0 захватывает и строки, созданные ровно в момент t
+		cur = storage.Cursor{LastCreatedAt: t}
+	} else {
+		n, err := strconv.Atoi(*id)
+		if err != nil {
+			return fmt.Errorf("invalid -id: %w", err)
+		}
+		cur, err = storage.CursorAtID(ctx, client, *stream, n)
+		if err != nil {
+			return fmt.Errorf("lookup row %d in %s: %w", n, *stream, err)
+		}
+	}
+
+	if err := store.Save(ctx, *consumer, *stream, cur); err != nil {
+		return err
+	}
+	fmt.Printf("%s/%s moved to created_at=%s id=%d\n", *consumer, *stream, formatTime(cur.LastCreatedAt), cur.LastID)
+	return nil
+}
+
+func checkStream(stream string) error {
+	switch stream {
+	case storage.StreamDomains, storage.StreamLinks:
+		return nil
+	default:
+		return fmt.Errorf("-stream must be %s or %s", storage.StreamDomains, storage.StreamLinks)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/internal/agent"
+	"github.com/zeshi09/go_web_parser_agent/internal/storage"
+)
+
+const filtersUsage = `usage:
+  agent filters dry-run [-file FILE | -expr EXPR] [-stream domains|social_links] [-since RFC3339] [-show N]
+
+dry-run прогоняет фильтры по уже лежащим в таблицах строкам и считает совпадения,
+ничего не отправляя; по умолчанию берётся FILTERS_FILE`
+
+// runFiltersCmd обрабатывает подкоманды "agent filters ..."
+func runFiltersCmd(ctx context.Context, client *ent.Client, args []string) error {
+	if len(args) == 0 || args[0] != "dry-run" {
+		return fmt.Errorf("unknown or missing subcommand\n%s", filtersUsage)
+	}
+
+	fs := flag.NewFlagSet("dry-run", flag.ContinueOnError)
+	file := fs.String("file", os.Getenv("FILTERS_FILE"), "filters file")
+	expr := fs.String("expr", "", "single CEL expression to test instead of the file")
+	stream := fs.String("stream", "", "check only this stream (default: all)")
+	since := fs.String("since", "", "check only rows created at or after this RFC3339 time")
+	show := fs.Int("show", 0, "print up to N matching rows per filter")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg := &agent.FilterConfig{}
+	switch {
+	case *expr != "":
+		cfg.Filters = []agent.FilterRule{{Name: "expr", Expr: *expr, Action: agent.FilterDrop}}
+	case *file != "":
+		var err error
+		if cfg, err = agent.LoadFilterConfig(*file); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("-file, -expr or FILTERS_FILE is required\n%s", filtersUsage)
+	}
+	filters, err := agent.CompileFilters(cfg)
+	if err != nil {
+		return err
+	}
+
+	var start storage.Cursor
+	if *since != "" {
+		t, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return fmt.Errorf("invalid -since: %w", err)
+		}
+		start.LastCreatedAt = t
+	}
+
+	streams := []string{storage.StreamDomains, storage.StreamLinks}
+	if *stream != "" {
+		if err := checkStream(*stream); err != nil {
+			return err
+		}
+		streams = []string{*stream}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FILTER\tACTION\tSTREAM\tSCANNED\tMATCHED\tERRORS")
+	var samples []string
+	for _, s := range streams {
+		res, err := dryRunStream(ctx, client, filters, s, start, *show)
+		if err != nil {
+			return fmt.Errorf("dry-run %s: %w", s, err)
+		}
+		for i, f := range filters.Rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", f.Name, f.Action, s, res.scanned, res.matched[i], res.errors[i])
+			for _, sample := range res.samples[i] {
+				samples = append(samples, f.Name+"\t"+sample)
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(samples) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "FILTER\tEVENT\tVALUE")
+		for _, s := range samples {
+			fmt.Fprintln(w, s)
+		}
+		return w.Flush()
+	}
+	return nil
+}
+
+type dryRunResult struct {
+	scanned int
+	matched []int
+	errors  []int
+	samples [][]string
+}
+
+// dryRunStream обходит таблицу keyset-курсором с позиции start; каждое правило проверяется независимо,
+// без учёта drop более ранних правил, чтобы счётчики показывали охват каждого выражения
+func dryRunStream(ctx context.Context, client *ent.Client, filters *agent.Filters, stream string, start storage.Cursor, show int) (dryRunResult, error) {
+	n := len(filters.Rules)
+	res := dryRunResult{matched: make([]int, n), errors: make([]int, n), samples: make([][]string, n)}
+
+	record := func(i int, matched bool, err error, id, value string) {
+		switch {
+		case err != nil:
+			res.errors[i]++
+		case matched:
+			res.matched[i]++
+			if len(res.samples[i]) < show {
+				res.samples[i] = append(res.samples[i], id+"\t"+value)
+			}
+		}
+	}
+
+	cur := start
+	for {
+		var last storage.Cursor
+		var size int
+		if stream == storage.StreamDomains {
+			batch, err := storage.CheckNewDomains(ctx, client, cur)
+			if err != nil {
+				return res, err
+			}
+			for _, e := range agent.DomainEvents(batch) {
+				for i, f := range filters.Rules {
+					matched, err := f.MatchDomain(e)
+					record(i, matched, err, e.EventID(), e.LandingDomain)
+				}
+			}
+			if size = len(batch); size > 0 {
+				last = storage.Cursor{LastCreatedAt: batch[size-1].CreatedAt, LastID: batch[size-1].ID}
+			}
+		} else {
+			batch, err := storage.CheckNewSocialLinks(ctx, client, cur)
+			if err != nil {
+				return res, err
+			}
+			for _, e := range agent.LinkEvents(batch) {
+				for i, f := range filters.Rules {
+					matched, err := f.MatchLink(e)
+					record(i, matched, err, e.EventID(), e.URL)
+				}
+			}
+			if size = len(batch); size > 0 {
+				last = storage.Cursor{LastCreatedAt: batch[size-1].CreatedAt, LastID: batch[size-1].ID}
+			}
+		}
+
+		res.scanned += size
+		if size < storage.PageSize {
+			return res, nil
+		}
+		cur = last
+	}
+}
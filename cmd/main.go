@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/zeshi09/go_web_parser_agent/ent"
@@ -18,54 +21,22 @@ import (
 	"github.com/zeshi09/go_web_parser_agent/internal/storage"
 )
 
-// пока храним курсоры для отслеживания изменений в базе данных в виде .json файлов
-const (
-	DomainStateFile = "domain_cursor.json"
-	LinkStateFile   = "link_cursor.json"
-)
-
 // structure для курсора
 var Curs storage.Cursor
 
-// функции загрузки и сохранения данных в курсор .json
-func loadCursor(filename string) (storage.Cursor, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return storage.Cursor{
-				LastCreatedAt: time.Time{},
-				LastID:        0,
-			}, nil
-		}
-		return storage.Cursor{}, err
-	}
-
-	var cursor storage.Cursor
-	if err := json.Unmarshal(data, &cursor); err != nil {
-		return storage.Cursor{}, err
-	}
-
-	return cursor, nil
-}
-func saveCursor(cursor storage.Cursor, filename string) error {
-	data, err := json.Marshal(cursor)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(filename, data, 0644)
-}
-
 // основной луп для работы с доменами в таблице
-func RunLoopDomain(ctx context.Context, client *ent.Client, webhook string, interval time.Duration, sof bool) error {
-	cur, err := loadCursor(DomainStateFile)
+func RunLoopDomain(ctx context.Context, client *ent.Client, store storage.CursorStore, consumer string, d agent.Delivery, interval, lookback time.Duration, wake <-chan struct{}, sof bool) error {
+	cur, err := store.Load(ctx, consumer, storage.StreamDomains)
 	if err != nil {
 		return fmt.Errorf("failed to load domain cursor: %w", err)
 	}
 
-	if err := agent.ScanAndNotifyDomains(ctx, client, &cur, webhook, sof); err != nil {
+	// первый проход молчит только у нового потребителя (курсор не сохранялся) без sof;
+	// сохранённый или выставленный через consumers seek курсор доставляется с места остановки
+	if err := agent.ScanAndNotifyDomains(ctx, client, &cur, d, sof || !cur.IsZero(), lookback); err != nil {
 		return fmt.Errorf("initial domain scan failed: %w", err)
 	}
-	if err := saveCursor(cur, DomainStateFile); err != nil {
+	if err := store.Save(ctx, consumer, storage.StreamDomains, cur); err != nil {
 		return fmt.Errorf("failed to save domain cursor: %w", err)
 	}
 
@@ -77,30 +48,33 @@ func RunLoopDomain(ctx context.Context, client *ent.Client, webhook string, inte
 		case <-ctx.Done():
 			return nil
 		case <-t.C:
-			if err := agent.ScanAndNotifyDomains(ctx, client, &cur, webhook, true); err != nil {
-				log.Error().Err(err).Msg("periodic scan failed")
-				continue
-			}
-			if err := saveCursor(cur, DomainStateFile); err != nil {
-				log.Error().Err(err).Msg("save cursor failed")
-			}
+		case <-wake:
+		}
+		if err := agent.ScanAndNotifyDomains(ctx, client, &cur, d, true, lookback); err != nil {
+			log.Error().Err(err).Msg("periodic scan failed")
+			continue
+		}
+		if err := store.Save(ctx, consumer, storage.StreamDomains, cur); err != nil {
+			log.Error().Err(err).Msg("save cursor failed")
 		}
 	}
 }
 
 // основной луп для работы с линками в таблице
-func RunLoopLink(ctx context.Context, client *ent.Client, webhook string, interval time.Duration, sof bool) error {
+func RunLoopLink(ctx context.Context, client *ent.Client, store storage.CursorStore, consumer string, d agent.Delivery, interval, lookback time.Duration, wake <-chan struct{}, sof bool) error {
 	// загружаем курсор, чтобы просмотреть состояние изменений
-	cur, err := loadCursor(LinkStateFile)
+	cur, err := store.Load(ctx, consumer, storage.StreamLinks)
 	if err != nil {
 		return fmt.Errorf("failed to load link cursor: %w", err)
 	}
 
 	// сканируем таблицу и вызываем notify, если что-то изменилось
-	if err := agent.ScanAndNotifyLinks(ctx, client, &cur, webhook, sof); err != nil {
+	// первый проход молчит только у нового потребителя (курсор не сохранялся) без sof;
+	// сохранённый или выставленный через consumers seek курсор доставляется с места остановки
+	if err := agent.ScanAndNotifyLinks(ctx, client, &cur, d, sof || !cur.IsZero(), lookback); err != nil {
 		return fmt.Errorf("initial link scan failed: %w", err)
 	}
-	if err := saveCursor(cur, LinkStateFile); err != nil {
+	if err := store.Save(ctx, consumer, storage.StreamLinks, cur); err != nil {
 		return fmt.Errorf("failed to save link cursor: %w", err)
 	}
 
@@ -108,19 +82,20 @@ func RunLoopLink(ctx context.Context, client *ent.Client, webhook string, interv
 	t := time.NewTicker(interval)
 	defer t.Stop()
 
-	// основной цикл
+	// основной цикл: сканируем по тикеру или по пробуждению от LISTEN/NOTIFY (в режиме опроса wake == nil)
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-t.C:
-			if err := agent.ScanAndNotifyLinks(ctx, client, &cur, webhook, true); err != nil {
-				log.Error().Err(err).Msg("periodic scan failed")
-				continue
-			}
-			if err := saveCursor(cur, LinkStateFile); err != nil {
-				log.Error().Err(err).Msg("save cursor failed")
-			}
+		case <-wake:
+		}
+		if err := agent.ScanAndNotifyLinks(ctx, client, &cur, d, true, lookback); err != nil {
+			log.Error().Err(err).Msg("periodic scan failed")
+			continue
+		}
+		if err := store.Save(ctx, consumer, storage.StreamLinks, cur); err != nil {
+			log.Error().Err(err).Msg("save cursor failed")
 		}
 	}
 }
@@ -135,39 +110,188 @@ func main() {
 		log.Error().Err(err).Msg("Failed to load .env")
 	}
 
-	// подгружаем значение webhook url для мм из .env
-	webhook := os.Getenv("MM_WEBHOOK")
-	if webhook == "" {
-		log.Fatal().Msg("MM_WEBHOOK env var is required")
-	}
-
 	// открываем базовый клиент для postgres с вызовом DSN метода для определения строки подключения из db.go
-	client, err := ent.Open("postgres", storage.LoadConfigFromEnv().DSN())
+	// *sql.DB держим отдельно: через него ставятся триггеры для LISTEN/NOTIFY
+	dsn := storage.LoadConfigFromEnv().DSN()
+	db, err := sql.Open(dialect.Postgres, dsn)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create db client")
 	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db)))
 	defer client.Close()
 
 	// обозначаем context
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	// выбираем хранилище курсоров: file (по умолчанию, .json в CURSOR_DIR) или postgres
+	storeKind := os.Getenv("CURSOR_STORE")
+	if storeKind == "postgres" {
+		if err := storage.MigrateAgentTables(ctx, client); err != nil {
+			log.Fatal().Err(err).Msg("Failed to migrate agent tables")
+		}
+	}
+	store, err := storage.NewCursorStore(storeKind, client, os.Getenv("CURSOR_DIR"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create cursor store")
+	}
+
+	// служебные команды для работы с курсорами потребителей
+	if len(os.Args) > 1 && os.Args[1] == "consumers" {
+		if err := runConsumersCmd(ctx, client, store, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("consumers command failed")
+		}
+		return
+	}
+
+	// переиндексация таблиц в Elasticsearch/OpenSearch
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		if err := runReindexCmd(ctx, client, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("reindex command failed")
+		}
+		return
+	}
+
+	// проверка фильтров на исторических строках
+	if len(os.Args) > 1 && os.Args[1] == "filters" {
+		if err := runFiltersCmd(ctx, client, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("filters command failed")
+		}
+		return
+	}
+
+	// собираем sink'и для уведомлений (MM_WEBHOOK и другие, см. notifiers.go)
+	notifiers, err := buildNotifiers()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure notifiers")
+	}
+	if len(notifiers) == 0 {
+		log.Fatal().Msg("no notifiers configured, set MM_WEBHOOK or another sink")
+	}
+	// буфер дайджеста живёт только в памяти: outbox отметил бы события доставленными до отправки письма
+	if os.Getenv("DELIVERY") == "outbox" {
+		for _, n := range notifiers {
+			if e, ok := n.(*agent.Email); ok && e.Window > 0 {
+				log.Fatal().Msg("SMTP_BATCH_WINDOW cannot be used with DELIVERY=outbox")
+			}
+		}
+	}
+	for _, n := range notifiers {
+		if r, ok := n.(agent.Runner); ok {
+			go r.Run(ctx)
+		}
+		// sink'и с постоянным соединением (брокеры) закрываем при выходе
+		if c, ok := n.(io.Closer); ok {
+			defer c.Close()
+		}
+	}
+
+	// ROUTES_FILE: правила решают, какие sink'и и каналы получают каждое событие (см. agent.RoutingConfig);
+	// без файла, а также sink'и, не упомянутые ни в одном маршруте, получают каждое событие
+	if path := os.Getenv("ROUTES_FILE"); path != "" {
+		routes, err := agent.LoadRoutingConfig(path)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load routes")
+		}
+		if notifiers, err = agent.RouteNotifiers(routes, notifiers); err != nil {
+			log.Fatal().Err(err).Msg("Failed to configure routes")
+		}
+	}
+
+	// FILTERS_FILE: выражения CEL отбрасывают события или проставляют им теги и severity до маршрутизации
+	if path := os.Getenv("FILTERS_FILE"); path != "" {
+		cfg, err := agent.LoadFilterConfig(path)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load filters")
+		}
+		filters, err := agent.CompileFilters(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to compile filters")
+		}
+		notifiers = agent.FilterNotifiers(filters, notifiers)
+	}
+
+	// имя потребителя, под которым агент хранит свои курсоры
+	consumer := os.Getenv("CONSUMER")
+	if consumer == "" {
+		consumer = storage.DefaultConsumer
+	}
+
 	// обозначаем переменные для запросов
 	interval := 30 * time.Second
+	if v := os.Getenv("SCAN_INTERVAL"); v != "" {
+		if interval, err = time.ParseDuration(v); err != nil {
+			log.Fatal().Err(err).Msg("invalid SCAN_INTERVAL")
+		}
+	}
+	// новый потребитель (без сохранённого курсора) по умолчанию молча догоняет таблицу до конца
 	sendOnFirst := false
 
+	// DELIVERY=outbox: циклы только кладут строки в outbox вместе с курсором, а отправляет диспетчер с повторами;
+	// по умолчанию шлём прямо из цикла
+	var delivery agent.Delivery = agent.Direct{Notifier: agent.NewFanout(notifiers)}
+	switch os.Getenv("DELIVERY") {
+	case "", "direct":
+	case "outbox":
+		if storeKind != "postgres" {
+			log.Fatal().Msg("DELIVERY=outbox requires CURSOR_STORE=postgres")
+		}
+		// в outbox каждая строка ставится в очередь отдельно для каждого sink'а
+		sinks := make(map[string]agent.Notifier, len(notifiers))
+		names := make([]string, 0, len(notifiers))
+		for _, n := range notifiers {
+			sinks[n.Name()] = n
+			names = append(names, n.Name())
+		}
+		go agent.NewDispatcher(client, consumer, sinks).Run(ctx)
+		delivery = &agent.OutboxWriter{Client: client, Consumer: consumer, Sinks: names}
+	default:
+		log.Fatal().Str("delivery", os.Getenv("DELIVERY")).Msg("unknown DELIVERY")
+	}
+
+	// окно look-back: строки, вставленные позади курсора не глубже окна, будут досланы (0 — выключено)
+	var lookback time.Duration
+	if v := os.Getenv("LOOKBACK"); v != "" {
+		if lookback, err = time.ParseDuration(v); err != nil {
+			log.Fatal().Err(err).Msg("invalid LOOKBACK")
+		}
+	}
+
+	// в режиме PUSH_MODE циклы будятся по NOTIFY, а тикер остаётся страховочным и срабатывает редко
+	var domainWake, linkWake <-chan struct{}
+	loopInterval := interval
+	if os.Getenv("PUSH_MODE") == "true" {
+		if err := storage.InstallNotifyTriggers(ctx, db); err != nil {
+			log.Fatal().Err(err).Msg("Failed to install notify triggers")
+		}
+		listener, err := storage.NewListener(dsn, interval)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to start listener")
+		}
+		go listener.Run(ctx)
+		domainWake = listener.Wake(storage.StreamDomains)
+		linkWake = listener.Wake(storage.StreamLinks)
+
+		loopInterval = 5 * time.Minute
+		if v := os.Getenv("PUSH_SAFETY_INTERVAL"); v != "" {
+			if loopInterval, err = time.ParseDuration(v); err != nil {
+				log.Fatal().Err(err).Msg("invalid PUSH_SAFETY_INTERVAL")
+			}
+		}
+	}
+
 	// канал с ошибками для корректной обработки горутин
 	errCh := make(chan error, 2)
 
 	// открываем две горутины, которые параллельно будут проверять таблицу с доменами и ссылками
 	go func() {
-		if err := RunLoopDomain(ctx, client, webhook, interval, sendOnFirst); err != nil {
+		if err := RunLoopDomain(ctx, client, store, consumer, delivery, loopInterval, lookback, domainWake, sendOnFirst); err != nil {
 			log.Error().Err(err).Msg("loop failed")
 			errCh <- err
 		}
 	}()
 	go func() {
-		if err := RunLoopLink(ctx, client, webhook, interval, sendOnFirst); err != nil {
+		if err := RunLoopLink(ctx, client, store, consumer, delivery, loopInterval, lookback, linkWake, sendOnFirst); err != nil {
 			log.Error().Err(err).Msg("loop failed")
 			errCh <- err
 		}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/internal/agent"
+)
+
+// buildNotifiers собирает sink'и из переменных окружения: sink включён, если задана его переменная
+func buildNotifiers() ([]agent.Notifier, error) {
+	var notifiers []agent.Notifier
+
+	// шаблоны текстовых сообщений и подписи: встроенные для LOCALE (ru или en), перекрытые файлами из TEMPLATES_DIR
+	templates, err := agent.LoadTemplates(os.Getenv("TEMPLATES_DIR"), os.Getenv("LOCALE"))
+	if err != nil {
+		return nil, fmt.Errorf("load templates: %w", err)
+	}
+
+	if webhook := os.Getenv("MM_WEBHOOK"); webhook != "" {
+		mm := agent.NewMattermost(webhook)
+		mm.Templates = templates
+		if v := os.Getenv("MM_MAX_POST_SIZE"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid MM_MAX_POST_SIZE: %w", err)
+			}
+			mm.MaxPostSize = n
+		}
+		// большие пачки публикуются сводкой с файлом через API, для этого нужен токен бота и ID канала
+		if v := os.Getenv("MM_ATTACH_THRESHOLD"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid MM_ATTACH_THRESHOLD: %w", err)
+			}
+			mm.AttachThreshold = n
+			mm.APIURL = strings.TrimRight(os.Getenv("MM_URL"), "/")
+			mm.APIToken = os.Getenv("MM_TOKEN")
+			mm.ChannelID = os.Getenv("MM_CHANNEL_ID")
+			if n > 0 && (mm.APIURL == "" || mm.APIToken == "" || mm.ChannelID == "") {
+				return nil, fmt.Errorf("MM_URL, MM_TOKEN and MM_CHANNEL_ID are required with MM_ATTACH_THRESHOLD")
+			}
+		}
+		if format := os.Getenv("MM_ATTACH_FORMAT"); format != "" {
+			if format != agent.MMAttachCSV && format != agent.MMAttachJSONL {
+				return nil, fmt.Errorf("unknown MM_ATTACH_FORMAT %q", format)
+			}
+			mm.AttachFormat = format
+		}
+		notifiers = append(notifiers, mm)
+	}
+
+	if webhook := os.Getenv("SLACK_WEBHOOK"); webhook != "" {
+		slack := agent.NewSlack(webhook)
+		slack.Templates = templates
+		notifiers = append(notifiers, slack)
+	}
+
+	if token := os.Getenv("TG_BOT_TOKEN"); token != "" {
+		chatID := os.Getenv("TG_CHAT_ID")
+		if chatID == "" {
+			return nil, fmt.Errorf("TG_CHAT_ID is required with TG_BOT_TOKEN")
+		}
+		tg := agent.NewTelegram(token, chatID)
+		tg.Templates = templates
+		if api := os.Getenv("TG_API_URL"); api != "" {
+			tg.APIURL = api
+		}
+		notifiers = append(notifiers, tg)
+	}
+
+	if webhook := os.Getenv("TEAMS_WEBHOOK"); webhook != "" {
+		teams := agent.NewTeams(webhook)
+		teams.Templates = templates
+		if v := os.Getenv("TEAMS_MAX_ITEMS"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TEAMS_MAX_ITEMS: %w", err)
+			}
+			teams.MaxItems = n
+		}
+		notifiers = append(notifiers, teams)
+	}
+
+	if webhook := os.Getenv("DISCORD_WEBHOOK"); webhook != "" {
+		discord := agent.NewDiscord(webhook)
+		discord.Templates = templates
+		notifiers = append(notifiers, discord)
+	}
+
+	if url := os.Getenv("WEBHOOK_URL"); url != "" {
+		secret := os.Getenv("WEBHOOK_SECRET")
+		if secret == "" {
+			return nil, fmt.Errorf("WEBHOOK_SECRET is required with WEBHOOK_URL")
+		}
+		notifiers = append(notifiers, agent.NewWebhook(url, secret))
+	}
+
+	if url := os.Getenv("CLOUDEVENTS_URL"); url != "" {
+		ce := agent.NewCloudEvents(url)
+		if source := os.Getenv("CLOUDEVENTS_SOURCE"); source != "" {
+			ce.Source = source
+		}
+		if mode := os.Getenv("CLOUDEVENTS_MODE"); mode != "" {
+			switch mode {
+			case agent.CloudEventsStructured, agent.CloudEventsBinary, agent.CloudEventsBatch:
+				ce.Mode = mode
+			default:
+				return nil, fmt.Errorf("unknown CLOUDEVENTS_MODE %q", mode)
+			}
+		}
+		notifiers = append(notifiers, ce)
+	}
+
+	if url := os.Getenv("NATS_URL"); url != "" {
+		prefix := os.Getenv("NATS_SUBJECT_PREFIX")
+		if prefix == "" {
+			prefix = "watcher"
+		}
+		n, err := agent.NewNATS(url, prefix, os.Getenv("NATS_JETSTREAM") == "true")
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	if brokers := splitList(os.Getenv("KAFKA_BROKERS")); len(brokers) > 0 {
+		cfg := agent.KafkaConfig{
+			Brokers:     brokers,
+			DomainTopic: os.Getenv("KAFKA_DOMAIN_TOPIC"),
+			LinkTopic:   os.Getenv("KAFKA_LINK_TOPIC"),
+			Acks:        os.Getenv("KAFKA_ACKS"),
+			Idempotent:  os.Getenv("KAFKA_IDEMPOTENT") != "false",
+		}
+		if cfg.DomainTopic == "" {
+			cfg.DomainTopic = "watcher.domains"
+		}
+		if cfg.LinkTopic == "" {
+			cfg.LinkTopic = "watcher.social_links"
+		}
+		k, err := agent.NewKafka(cfg)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, k)
+	}
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		domainStream := os.Getenv("REDIS_DOMAIN_STREAM")
+		if domainStream == "" {
+			domainStream = "watcher:domains"
+		}
+		linkStream := os.Getenv("REDIS_LINK_STREAM")
+		if linkStream == "" {
+			linkStream = "watcher:social_links"
+		}
+		var maxLen int64 = 100000
+		if v := os.Getenv("REDIS_STREAM_MAXLEN"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid REDIS_STREAM_MAXLEN: %w", err)
+			}
+			maxLen = n
+		}
+		r, err := agent.NewRedisStreams(url, domainStream, linkStream, maxLen)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, r)
+	}
+
+	if url := os.Getenv("AMQP_URL"); url != "" {
+		exchange := os.Getenv("AMQP_EXCHANGE")
+		if exchange == "" {
+			exchange = "watcher"
+		}
+		a, err := agent.NewAMQP(url, exchange)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, a)
+	}
+
+	if addr := os.Getenv("SYSLOG_ADDR"); addr != "" {
+		network := os.Getenv("SYSLOG_NETWORK")
+		if network == "" {
+			network = "udp"
+		}
+		format := os.Getenv("SYSLOG_FORMAT")
+		if format == "" {
+			format = agent.SyslogCEF
+		}
+		if format != agent.SyslogCEF && format != agent.SyslogLEEF {
+			return nil, fmt.Errorf("unknown SYSLOG_FORMAT %q", format)
+		}
+		sl := agent.NewSyslog(network, addr, format)
+		if caFile := os.Getenv("SYSLOG_TLS_CA"); caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("read SYSLOG_TLS_CA: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates in SYSLOG_TLS_CA")
+			}
+			host, _, _ := net.SplitHostPort(addr)
+			sl.TLSConfig = &tls.Config{ServerName: host, RootCAs: pool}
+		}
+		notifiers = append(notifiers, sl)
+	}
+
+	if url := os.Getenv("SPLUNK_HEC_URL"); url != "" {
+		token := os.Getenv("SPLUNK_HEC_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("SPLUNK_HEC_TOKEN is required with SPLUNK_HEC_URL")
+		}
+		splunk := agent.NewSplunk(url, token)
+		splunk.Index = os.Getenv("SPLUNK_INDEX")
+		if st := os.Getenv("SPLUNK_SOURCETYPE"); st != "" {
+			splunk.SourceType = st
+		}
+		if v := os.Getenv("SPLUNK_BATCH_SIZE"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SPLUNK_BATCH_SIZE: %w", err)
+			}
+			splunk.BatchSize = n
+		}
+		splunk.Gzip = os.Getenv("SPLUNK_GZIP") == "true"
+		splunk.UseAck = os.Getenv("SPLUNK_ACK") == "true"
+		if v := os.Getenv("SPLUNK_ACK_TIMEOUT"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SPLUNK_ACK_TIMEOUT: %w", err)
+			}
+			splunk.AckTimeout = d
+		}
+		notifiers = append(notifiers, splunk)
+	}
+
+	es, err := buildElastic()
+	if err != nil {
+		return nil, err
+	}
+	if es != nil {
+		notifiers = append(notifiers, es)
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		to := splitList(os.Getenv("SMTP_TO"))
+		if len(to) == 0 || os.Getenv("SMTP_FROM") == "" {
+			return nil, fmt.Errorf("SMTP_FROM and SMTP_TO are required with SMTP_HOST")
+		}
+		// адреса проверяем при старте, а не при первой отправке дайджеста
+		if _, err := mail.ParseAddress(os.Getenv("SMTP_FROM")); err != nil {
+			return nil, fmt.Errorf("invalid SMTP_FROM: %w", err)
+		}
+		for _, addr := range to {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				return nil, fmt.Errorf("invalid SMTP_TO address %q: %w", addr, err)
+			}
+		}
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		email := agent.NewEmail(host, port, os.Getenv("SMTP_FROM"), to)
+		email.Username = os.Getenv("SMTP_USER")
+		email.Password = os.Getenv("SMTP_PASSWORD")
+		email.Templates = templates
+		if mode := os.Getenv("SMTP_TLS"); mode != "" {
+			switch mode {
+			case agent.SMTPStartTLS, agent.SMTPTLS, agent.SMTPPlain:
+				email.TLSMode = mode
+			default:
+				return nil, fmt.Errorf("unknown SMTP_TLS %q", mode)
+			}
+		}
+		if v := os.Getenv("SMTP_BATCH_WINDOW"); v != "" {
+			window, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_BATCH_WINDOW: %w", err)
+			}
+			email.Window = window
+		}
+		if v := os.Getenv("SMTP_MAX_BUFFERED"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_MAX_BUFFERED: %w", err)
+			}
+			email.MaxBuffered = n
+		}
+		notifiers = append(notifiers, email)
+	}
+
+	return notifiers, nil
+}
+
+// buildElastic настраивает индексатор по ELASTIC_*, nil — если ELASTIC_URL не задан.
+// Используется и как sink, и командой reindex
+func buildElastic() (*agent.Elastic, error) {
+	url := os.Getenv("ELASTIC_URL")
+	if url == "" {
+		return nil, nil
+	}
+	prefix := os.Getenv("ELASTIC_INDEX_PREFIX")
+	if prefix == "" {
+		prefix = "watcher"
+	}
+	es := agent.NewElastic(url, prefix)
+	es.Username = os.Getenv("ELASTIC_USERNAME")
+	es.Password = os.Getenv("ELASTIC_PASSWORD")
+	es.APIKey = os.Getenv("ELASTIC_API_KEY")
+	if v := os.Getenv("ELASTIC_BULK_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ELASTIC_BULK_SIZE: %w", err)
+		}
+		es.BulkSize = n
+	}
+	return es, nil
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
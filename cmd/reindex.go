@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/internal/agent"
+	"github.com/zeshi09/go_web_parser_agent/internal/storage"
+)
+
+const reindexUsage = `usage:
+  agent reindex [-stream domains|social_links]
+
+переиндексирует таблицы парсера целиком в ELASTIC_URL; документы upsert'ятся по ID,
+поэтому команду можно запускать рядом с работающим агентом и повторять после сбоя`
+
+// runReindexCmd обрабатывает "agent reindex": backfill индексов Elasticsearch/OpenSearch
+func runReindexCmd(ctx context.Context, client *ent.Client, args []string) error {
+	fs := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	stream := fs.String("stream", "", "reindex only this stream (default: all)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, reindexUsage)
+	}
+
+	es, err := buildElastic()
+	if err != nil {
+		return err
+	}
+	if es == nil {
+		return fmt.Errorf("ELASTIC_URL is not set\n%s", reindexUsage)
+	}
+	if err := es.EnsureTemplates(ctx); err != nil {
+		return err
+	}
+
+	streams := []string{storage.StreamDomains, storage.StreamLinks}
+	if *stream != "" {
+		if err := checkStream(*stream); err != nil {
+			return err
+		}
+		streams = []string{*stream}
+	}
+
+	for _, s := range streams {
+		var n int
+		var err error
+		if s == storage.StreamDomains {
+			n, err = reindexDomains(ctx, client, es)
+		} else {
+			n, err = reindexLinks(ctx, client, es)
+		}
+		if err != nil {
+			return fmt.Errorf("reindex %s: %w", s, err)
+		}
+		fmt.Printf("%s: %d rows indexed\n", s, n)
+	}
+	return nil
+}
+
+// таблицы обходятся тем же keyset-курсором, что и в циклах сканирования, но с нуля и в памяти
+func reindexDomains(ctx context.Context, client *ent.Client, es *agent.Elastic) (int, error) {
+	var cur storage.Cursor
+	total := 0
+	for {
+		batch, err := storage.CheckNewDomains(ctx, client, cur)
+		if err != nil {
+			return total, err
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+		if err := es.NotifyDomains(ctx, agent.DomainEvents(batch)); err != nil {
+			return total, err
+		}
+		last := batch[len(batch)-1]
+		cur.LastCreatedAt, cur.LastID = last.CreatedAt, last.ID
+		total += len(batch)
+		log.Info().Int("indexed_domains", total).Msg("reindex in progress")
+		if len(batch) < storage.PageSize {
+			return total, nil
+		}
+	}
+}
+
+func reindexLinks(ctx context.Context, client *ent.Client, es *agent.Elastic) (int, error) {
+	var cur storage.Cursor
+	total := 0
+	for {
+		batch, err := storage.CheckNewSocialLinks(ctx, client, cur)
+		if err != nil {
+			return total, err
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+		if err := es.NotifyLinks(ctx, agent.LinkEvents(batch)); err != nil {
+			return total, err
+		}
+		last := batch[len(batch)-1]
+		cur.LastCreatedAt, cur.LastID = last.CreatedAt, last.ID
+		total += len(batch)
+		log.Info().Int("indexed_links", total).Msg("reindex in progress")
+		if len(batch) < storage.PageSize {
+			return total, nil
+		}
+	}
+}
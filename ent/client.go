@@ -14,7 +14,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
 	"github.com/zeshi09/go_web_parser_agent/ent/domain"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
 	"github.com/zeshi09/go_web_parser_agent/ent/sociallink"
 )
 
@@ -23,8 +25,12 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// ConsumerCursor is the client for interacting with the ConsumerCursor builders.
+	ConsumerCursor *ConsumerCursorClient
 	// Domain is the client for interacting with the Domain builders.
 	Domain *DomainClient
+	// Outbox is the client for interacting with the Outbox builders.
+	Outbox *OutboxClient
 	// SocialLink is the client for interacting with the SocialLink builders.
 	SocialLink *SocialLinkClient
 }
@@ -38,7 +44,9 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.ConsumerCursor = NewConsumerCursorClient(c.config)
 	c.Domain = NewDomainClient(c.config)
+	c.Outbox = NewOutboxClient(c.config)
 	c.SocialLink = NewSocialLinkClient(c.config)
 }
 
@@ -130,10 +138,12 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:        ctx,
-		config:     cfg,
-		Domain:     NewDomainClient(cfg),
-		SocialLink: NewSocialLinkClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		ConsumerCursor: NewConsumerCursorClient(cfg),
+		Domain:         NewDomainClient(cfg),
+		Outbox:         NewOutboxClient(cfg),
+		SocialLink:     NewSocialLinkClient(cfg),
 	}, nil
 }
 
@@ -151,17 +161,19 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:        ctx,
-		config:     cfg,
-		Domain:     NewDomainClient(cfg),
-		SocialLink: NewSocialLinkClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		ConsumerCursor: NewConsumerCursorClient(cfg),
+		Domain:         NewDomainClient(cfg),
+		Outbox:         NewOutboxClient(cfg),
+		SocialLink:     NewSocialLinkClient(cfg),
 	}, nil
 }
 
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		Domain.
+//		ConsumerCursor.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -183,22 +195,30 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
+	c.ConsumerCursor.Use(hooks...)
 	c.Domain.Use(hooks...)
+	c.Outbox.Use(hooks...)
 	c.SocialLink.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
+	c.ConsumerCursor.Intercept(interceptors...)
 	c.Domain.Intercept(interceptors...)
+	c.Outbox.Intercept(interceptors...)
 	c.SocialLink.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *ConsumerCursorMutation:
+		return c.ConsumerCursor.mutate(ctx, m)
 	case *DomainMutation:
 		return c.Domain.mutate(ctx, m)
+	case *OutboxMutation:
+		return c.Outbox.mutate(ctx, m)
 	case *SocialLinkMutation:
 		return c.SocialLink.mutate(ctx, m)
 	default:
@@ -206,6 +226,139 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	}
 }
 
+// ConsumerCursorClient is a client for the ConsumerCursor schema.
+type ConsumerCursorClient struct {
+	config
+}
+
+// NewConsumerCursorClient returns a client for the ConsumerCursor from the given config.
+func NewConsumerCursorClient(c config) *ConsumerCursorClient {
+	return &ConsumerCursorClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `consumercursor.Hooks(f(g(h())))`.
+func (c *ConsumerCursorClient) Use(hooks ...Hook) {
+	c.hooks.ConsumerCursor = append(c.hooks.ConsumerCursor, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `consumercursor.Intercept(f(g(h())))`.
+func (c *ConsumerCursorClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ConsumerCursor = append(c.inters.ConsumerCursor, interceptors...)
+}
+
+// Create returns a builder for creating a ConsumerCursor entity.
+func (c *ConsumerCursorClient) Create() *ConsumerCursorCreate {
+	mutation := newConsumerCursorMutation(c.config, OpCreate)
+	return &ConsumerCursorCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ConsumerCursor entities.
+func (c *ConsumerCursorClient) CreateBulk(builders ...*ConsumerCursorCreate) *ConsumerCursorCreateBulk {
+	return &ConsumerCursorCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *ConsumerCursorClient) MapCreateBulk(slice any, setFunc func(*ConsumerCursorCreate, int)) *ConsumerCursorCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &ConsumerCursorCreateBulk{err: fmt.Errorf("calling to ConsumerCursorClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*ConsumerCursorCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &ConsumerCursorCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ConsumerCursor.
+func (c *ConsumerCursorClient) Update() *ConsumerCursorUpdate {
+	mutation := newConsumerCursorMutation(c.config, OpUpdate)
+	return &ConsumerCursorUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ConsumerCursorClient) UpdateOne(_m *ConsumerCursor) *ConsumerCursorUpdateOne {
+	mutation := newConsumerCursorMutation(c.config, OpUpdateOne, withConsumerCursor(_m))
+	return &ConsumerCursorUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ConsumerCursorClient) UpdateOneID(id int) *ConsumerCursorUpdateOne {
+	mutation := newConsumerCursorMutation(c.config, OpUpdateOne, withConsumerCursorID(id))
+	return &ConsumerCursorUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ConsumerCursor.
+func (c *ConsumerCursorClient) Delete() *ConsumerCursorDelete {
+	mutation := newConsumerCursorMutation(c.config, OpDelete)
+	return &ConsumerCursorDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ConsumerCursorClient) DeleteOne(_m *ConsumerCursor) *ConsumerCursorDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ConsumerCursorClient) DeleteOneID(id int) *ConsumerCursorDeleteOne {
+	builder := c.Delete().Where(consumercursor.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ConsumerCursorDeleteOne{builder}
+}
+
+// Query returns a query builder for ConsumerCursor.
+func (c *ConsumerCursorClient) Query() *ConsumerCursorQuery {
+	return &ConsumerCursorQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeConsumerCursor},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ConsumerCursor entity by its id.
+func (c *ConsumerCursorClient) Get(ctx context.Context, id int) (*ConsumerCursor, error) {
+	return c.Query().Where(consumercursor.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ConsumerCursorClient) GetX(ctx context.Context, id int) *ConsumerCursor {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ConsumerCursorClient) Hooks() []Hook {
+	return c.hooks.ConsumerCursor
+}
+
+// Interceptors returns the client interceptors.
+func (c *ConsumerCursorClient) Interceptors() []Interceptor {
+	return c.inters.ConsumerCursor
+}
+
+func (c *ConsumerCursorClient) mutate(ctx context.Context, m *ConsumerCursorMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ConsumerCursorCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ConsumerCursorUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ConsumerCursorUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ConsumerCursorDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown ConsumerCursor mutation op: %q", m.Op())
+	}
+}
+
 // DomainClient is a client for the Domain schema.
 type DomainClient struct {
 	config
@@ -339,6 +492,139 @@ func (c *DomainClient) mutate(ctx context.Context, m *DomainMutation) (Value, er
 	}
 }
 
+// OutboxClient is a client for the Outbox schema.
+type OutboxClient struct {
+	config
+}
+
+// NewOutboxClient returns a client for the Outbox from the given config.
+func NewOutboxClient(c config) *OutboxClient {
+	return &OutboxClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `outbox.Hooks(f(g(h())))`.
+func (c *OutboxClient) Use(hooks ...Hook) {
+	c.hooks.Outbox = append(c.hooks.Outbox, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `outbox.Intercept(f(g(h())))`.
+func (c *OutboxClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Outbox = append(c.inters.Outbox, interceptors...)
+}
+
+// Create returns a builder for creating a Outbox entity.
+func (c *OutboxClient) Create() *OutboxCreate {
+	mutation := newOutboxMutation(c.config, OpCreate)
+	return &OutboxCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Outbox entities.
+func (c *OutboxClient) CreateBulk(builders ...*OutboxCreate) *OutboxCreateBulk {
+	return &OutboxCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *OutboxClient) MapCreateBulk(slice any, setFunc func(*OutboxCreate, int)) *OutboxCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &OutboxCreateBulk{err: fmt.Errorf("calling to OutboxClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*OutboxCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &OutboxCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Outbox.
+func (c *OutboxClient) Update() *OutboxUpdate {
+	mutation := newOutboxMutation(c.config, OpUpdate)
+	return &OutboxUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *OutboxClient) UpdateOne(_m *Outbox) *OutboxUpdateOne {
+	mutation := newOutboxMutation(c.config, OpUpdateOne, withOutbox(_m))
+	return &OutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *OutboxClient) UpdateOneID(id int) *OutboxUpdateOne {
+	mutation := newOutboxMutation(c.config, OpUpdateOne, withOutboxID(id))
+	return &OutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Outbox.
+func (c *OutboxClient) Delete() *OutboxDelete {
+	mutation := newOutboxMutation(c.config, OpDelete)
+	return &OutboxDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *OutboxClient) DeleteOne(_m *Outbox) *OutboxDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *OutboxClient) DeleteOneID(id int) *OutboxDeleteOne {
+	builder := c.Delete().Where(outbox.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &OutboxDeleteOne{builder}
+}
+
+// Query returns a query builder for Outbox.
+func (c *OutboxClient) Query() *OutboxQuery {
+	return &OutboxQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeOutbox},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Outbox entity by its id.
+func (c *OutboxClient) Get(ctx context.Context, id int) (*Outbox, error) {
+	return c.Query().Where(outbox.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *OutboxClient) GetX(ctx context.Context, id int) *Outbox {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *OutboxClient) Hooks() []Hook {
+	return c.hooks.Outbox
+}
+
+// Interceptors returns the client interceptors.
+func (c *OutboxClient) Interceptors() []Interceptor {
+	return c.inters.Outbox
+}
+
+func (c *OutboxClient) mutate(ctx context.Context, m *OutboxMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&OutboxCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&OutboxUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&OutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&OutboxDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Outbox mutation op: %q", m.Op())
+	}
+}
+
 // SocialLinkClient is a client for the SocialLink schema.
 type SocialLinkClient struct {
 	config
@@ -475,9 +761,9 @@ func (c *SocialLinkClient) mutate(ctx context.Context, m *SocialLinkMutation) (V
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Domain, SocialLink []ent.Hook
+		ConsumerCursor, Domain, Outbox, SocialLink []ent.Hook
 	}
 	inters struct {
-		Domain, SocialLink []ent.Interceptor
+		ConsumerCursor, Domain, Outbox, SocialLink []ent.Interceptor
 	}
 )
@@ -0,0 +1,180 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
+)
+
+// ConsumerCursor is the model entity for the ConsumerCursor schema.
+type ConsumerCursor struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Consumer name owning this cursor
+	Consumer string `json:"consumer,omitempty"`
+	// Watched table (domains, social_links)
+	Stream string `json:"stream,omitempty"`
+	// created_at of the last processed row
+	LastCreatedAt time.Time `json:"last_created_at,omitempty"`
+	// ID of the last processed row
+	LastID int `json:"last_id,omitempty"`
+	// IDs already processed inside the look-back window
+	Recent map[int]time.Time `json:"recent,omitempty"`
+	// Start of the look-back window after recent was trimmed to its cap
+	RecentSince *time.Time `json:"recent_since,omitempty"`
+	// When the cursor was last moved
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ConsumerCursor) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case consumercursor.FieldRecent:
+			values[i] = new([]byte)
+		case consumercursor.FieldID, consumercursor.FieldLastID:
+			values[i] = new(sql.NullInt64)
+		case consumercursor.FieldConsumer, consumercursor.FieldStream:
+			values[i] = new(sql.NullString)
+		case consumercursor.FieldLastCreatedAt, consumercursor.FieldRecentSince, consumercursor.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ConsumerCursor fields.
+func (_m *ConsumerCursor) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case consumercursor.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case consumercursor.FieldConsumer:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field consumer", values[i])
+			} else if value.Valid {
+				_m.Consumer = value.String
+			}
+		case consumercursor.FieldStream:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field stream", values[i])
+			} else if value.Valid {
+				_m.Stream = value.String
+			}
+		case consumercursor.FieldLastCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_created_at", values[i])
+			} else if value.Valid {
+				_m.LastCreatedAt = value.Time
+			}
+		case consumercursor.FieldLastID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field last_id", values[i])
+			} else if value.Valid {
+				_m.LastID = int(value.Int64)
+			}
+		case consumercursor.FieldRecent:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field recent", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Recent); err != nil {
+					return fmt.Errorf("unmarshal field recent: %w", err)
+				}
+			}
+		case consumercursor.FieldRecentSince:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field recent_since", values[i])
+			} else if value.Valid {
+				_m.RecentSince = new(time.Time)
+				*_m.RecentSince = value.Time
+			}
+		case consumercursor.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ConsumerCursor.
+// This includes values selected through modifiers, order, etc.
+func (_m *ConsumerCursor) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ConsumerCursor.
+// Note that you need to call ConsumerCursor.Unwrap() before calling this method if this ConsumerCursor
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *ConsumerCursor) Update() *ConsumerCursorUpdateOne {
+	return NewConsumerCursorClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the ConsumerCursor entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *ConsumerCursor) Unwrap() *ConsumerCursor {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ConsumerCursor is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *ConsumerCursor) String() string {
+	var builder strings.Builder
+	builder.WriteString("ConsumerCursor(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("consumer=")
+	builder.WriteString(_m.Consumer)
+	builder.WriteString(", ")
+	builder.WriteString("stream=")
+	builder.WriteString(_m.Stream)
+	builder.WriteString(", ")
+	builder.WriteString("last_created_at=")
+	builder.WriteString(_m.LastCreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("last_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LastID))
+	builder.WriteString(", ")
+	builder.WriteString("recent=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Recent))
+	builder.WriteString(", ")
+	if v := _m.RecentSince; v != nil {
+		builder.WriteString("recent_since=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ConsumerCursors is a parsable slice of ConsumerCursor.
+type ConsumerCursors []*ConsumerCursor
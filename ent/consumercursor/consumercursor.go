@@ -0,0 +1,101 @@
+// Code generated by ent, DO NOT EDIT.
+
+package consumercursor
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the consumercursor type in the database.
+	Label = "consumer_cursor"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldConsumer holds the string denoting the consumer field in the database.
+	FieldConsumer = "consumer"
+	// FieldStream holds the string denoting the stream field in the database.
+	FieldStream = "stream"
+	// FieldLastCreatedAt holds the string denoting the last_created_at field in the database.
+	FieldLastCreatedAt = "last_created_at"
+	// FieldLastID holds the string denoting the last_id field in the database.
+	FieldLastID = "last_id"
+	// FieldRecent holds the string denoting the recent field in the database.
+	FieldRecent = "recent"
+	// FieldRecentSince holds the string denoting the recent_since field in the database.
+	FieldRecentSince = "recent_since"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the consumercursor in the database.
+	Table = "consumer_cursors"
+)
+
+// Columns holds all SQL columns for consumercursor fields.
+var Columns = []string{
+	FieldID,
+	FieldConsumer,
+	FieldStream,
+	FieldLastCreatedAt,
+	FieldLastID,
+	FieldRecent,
+	FieldRecentSince,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultLastID holds the default value on creation for the "last_id" field.
+	DefaultLastID int
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the ConsumerCursor queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByConsumer orders the results by the consumer field.
+func ByConsumer(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldConsumer, opts...).ToFunc()
+}
+
+// ByStream orders the results by the stream field.
+func ByStream(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStream, opts...).ToFunc()
+}
+
+// ByLastCreatedAt orders the results by the last_created_at field.
+func ByLastCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastCreatedAt, opts...).ToFunc()
+}
+
+// ByLastID orders the results by the last_id field.
+func ByLastID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastID, opts...).ToFunc()
+}
+
+// ByRecentSince orders the results by the recent_since field.
+func ByRecentSince(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRecentSince, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
@@ -0,0 +1,410 @@
+// Code generated by ent, DO NOT EDIT.
+
+package consumercursor
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/zeshi09/go_web_parser_agent/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldID, id))
+}
+
+// Consumer applies equality check predicate on the "consumer" field. It's identical to ConsumerEQ.
+func Consumer(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldConsumer, v))
+}
+
+// Stream applies equality check predicate on the "stream" field. It's identical to StreamEQ.
+func Stream(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldStream, v))
+}
+
+// LastCreatedAt applies equality check predicate on the "last_created_at" field. It's identical to LastCreatedAtEQ.
+func LastCreatedAt(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldLastCreatedAt, v))
+}
+
+// LastID applies equality check predicate on the "last_id" field. It's identical to LastIDEQ.
+func LastID(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldLastID, v))
+}
+
+// RecentSince applies equality check predicate on the "recent_since" field. It's identical to RecentSinceEQ.
+func RecentSince(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldRecentSince, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// ConsumerEQ applies the EQ predicate on the "consumer" field.
+func ConsumerEQ(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldConsumer, v))
+}
+
+// ConsumerNEQ applies the NEQ predicate on the "consumer" field.
+func ConsumerNEQ(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldConsumer, v))
+}
+
+// ConsumerIn applies the In predicate on the "consumer" field.
+func ConsumerIn(vs ...string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldConsumer, vs...))
+}
+
+// ConsumerNotIn applies the NotIn predicate on the "consumer" field.
+func ConsumerNotIn(vs ...string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldConsumer, vs...))
+}
+
+// ConsumerGT applies the GT predicate on the "consumer" field.
+func ConsumerGT(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldConsumer, v))
+}
+
+// ConsumerGTE applies the GTE predicate on the "consumer" field.
+func ConsumerGTE(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldConsumer, v))
+}
+
+// ConsumerLT applies the LT predicate on the "consumer" field.
+func ConsumerLT(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldConsumer, v))
+}
+
+// ConsumerLTE applies the LTE predicate on the "consumer" field.
+func ConsumerLTE(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldConsumer, v))
+}
+
+// ConsumerContains applies the Contains predicate on the "consumer" field.
+func ConsumerContains(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldContains(FieldConsumer, v))
+}
+
+// ConsumerHasPrefix applies the HasPrefix predicate on the "consumer" field.
+func ConsumerHasPrefix(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldHasPrefix(FieldConsumer, v))
+}
+
+// ConsumerHasSuffix applies the HasSuffix predicate on the "consumer" field.
+func ConsumerHasSuffix(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldHasSuffix(FieldConsumer, v))
+}
+
+// ConsumerEqualFold applies the EqualFold predicate on the "consumer" field.
+func ConsumerEqualFold(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEqualFold(FieldConsumer, v))
+}
+
+// ConsumerContainsFold applies the ContainsFold predicate on the "consumer" field.
+func ConsumerContainsFold(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldContainsFold(FieldConsumer, v))
+}
+
+// StreamEQ applies the EQ predicate on the "stream" field.
+func StreamEQ(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldStream, v))
+}
+
+// StreamNEQ applies the NEQ predicate on the "stream" field.
+func StreamNEQ(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldStream, v))
+}
+
+// StreamIn applies the In predicate on the "stream" field.
+func StreamIn(vs ...string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldStream, vs...))
+}
+
+// StreamNotIn applies the NotIn predicate on the "stream" field.
+func StreamNotIn(vs ...string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldStream, vs...))
+}
+
+// StreamGT applies the GT predicate on the "stream" field.
+func StreamGT(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldStream, v))
+}
+
+// StreamGTE applies the GTE predicate on the "stream" field.
+func StreamGTE(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldStream, v))
+}
+
+// StreamLT applies the LT predicate on the "stream" field.
+func StreamLT(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldStream, v))
+}
+
+// StreamLTE applies the LTE predicate on the "stream" field.
+func StreamLTE(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldStream, v))
+}
+
+// StreamContains applies the Contains predicate on the "stream" field.
+func StreamContains(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldContains(FieldStream, v))
+}
+
+// StreamHasPrefix applies the HasPrefix predicate on the "stream" field.
+func StreamHasPrefix(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldHasPrefix(FieldStream, v))
+}
+
+// StreamHasSuffix applies the HasSuffix predicate on the "stream" field.
+func StreamHasSuffix(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldHasSuffix(FieldStream, v))
+}
+
+// StreamEqualFold applies the EqualFold predicate on the "stream" field.
+func StreamEqualFold(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEqualFold(FieldStream, v))
+}
+
+// StreamContainsFold applies the ContainsFold predicate on the "stream" field.
+func StreamContainsFold(v string) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldContainsFold(FieldStream, v))
+}
+
+// LastCreatedAtEQ applies the EQ predicate on the "last_created_at" field.
+func LastCreatedAtEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldLastCreatedAt, v))
+}
+
+// LastCreatedAtNEQ applies the NEQ predicate on the "last_created_at" field.
+func LastCreatedAtNEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldLastCreatedAt, v))
+}
+
+// LastCreatedAtIn applies the In predicate on the "last_created_at" field.
+func LastCreatedAtIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldLastCreatedAt, vs...))
+}
+
+// LastCreatedAtNotIn applies the NotIn predicate on the "last_created_at" field.
+func LastCreatedAtNotIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldLastCreatedAt, vs...))
+}
+
+// LastCreatedAtGT applies the GT predicate on the "last_created_at" field.
+func LastCreatedAtGT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldLastCreatedAt, v))
+}
+
+// LastCreatedAtGTE applies the GTE predicate on the "last_created_at" field.
+func LastCreatedAtGTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldLastCreatedAt, v))
+}
+
+// LastCreatedAtLT applies the LT predicate on the "last_created_at" field.
+func LastCreatedAtLT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldLastCreatedAt, v))
+}
+
+// LastCreatedAtLTE applies the LTE predicate on the "last_created_at" field.
+func LastCreatedAtLTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldLastCreatedAt, v))
+}
+
+// LastIDEQ applies the EQ predicate on the "last_id" field.
+func LastIDEQ(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldLastID, v))
+}
+
+// LastIDNEQ applies the NEQ predicate on the "last_id" field.
+func LastIDNEQ(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldLastID, v))
+}
+
+// LastIDIn applies the In predicate on the "last_id" field.
+func LastIDIn(vs ...int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldLastID, vs...))
+}
+
+// LastIDNotIn applies the NotIn predicate on the "last_id" field.
+func LastIDNotIn(vs ...int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldLastID, vs...))
+}
+
+// LastIDGT applies the GT predicate on the "last_id" field.
+func LastIDGT(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldLastID, v))
+}
+
+// LastIDGTE applies the GTE predicate on the "last_id" field.
+func LastIDGTE(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldLastID, v))
+}
+
+// LastIDLT applies the LT predicate on the "last_id" field.
+func LastIDLT(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldLastID, v))
+}
+
+// LastIDLTE applies the LTE predicate on the "last_id" field.
+func LastIDLTE(v int) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldLastID, v))
+}
+
+// RecentIsNil applies the IsNil predicate on the "recent" field.
+func RecentIsNil() predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIsNull(FieldRecent))
+}
+
+// RecentNotNil applies the NotNil predicate on the "recent" field.
+func RecentNotNil() predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotNull(FieldRecent))
+}
+
+// RecentSinceEQ applies the EQ predicate on the "recent_since" field.
+func RecentSinceEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldRecentSince, v))
+}
+
+// RecentSinceNEQ applies the NEQ predicate on the "recent_since" field.
+func RecentSinceNEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldRecentSince, v))
+}
+
+// RecentSinceIn applies the In predicate on the "recent_since" field.
+func RecentSinceIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldRecentSince, vs...))
+}
+
+// RecentSinceNotIn applies the NotIn predicate on the "recent_since" field.
+func RecentSinceNotIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldRecentSince, vs...))
+}
+
+// RecentSinceGT applies the GT predicate on the "recent_since" field.
+func RecentSinceGT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldRecentSince, v))
+}
+
+// RecentSinceGTE applies the GTE predicate on the "recent_since" field.
+func RecentSinceGTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldRecentSince, v))
+}
+
+// RecentSinceLT applies the LT predicate on the "recent_since" field.
+func RecentSinceLT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldRecentSince, v))
+}
+
+// RecentSinceLTE applies the LTE predicate on the "recent_since" field.
+func RecentSinceLTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldRecentSince, v))
+}
+
+// RecentSinceIsNil applies the IsNil predicate on the "recent_since" field.
+func RecentSinceIsNil() predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIsNull(FieldRecentSince))
+}
+
+// RecentSinceNotNil applies the NotNil predicate on the "recent_since" field.
+func RecentSinceNotNil() predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotNull(FieldRecentSince))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ConsumerCursor) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ConsumerCursor) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ConsumerCursor) predicate.ConsumerCursor {
+	return predicate.ConsumerCursor(sql.NotPredicates(p))
+}
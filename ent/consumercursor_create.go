@@ -0,0 +1,294 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
+)
+
+// ConsumerCursorCreate is the builder for creating a ConsumerCursor entity.
+type ConsumerCursorCreate struct {
+	config
+	mutation *ConsumerCursorMutation
+	hooks    []Hook
+}
+
+// SetConsumer sets the "consumer" field.
+func (_c *ConsumerCursorCreate) SetConsumer(v string) *ConsumerCursorCreate {
+	_c.mutation.SetConsumer(v)
+	return _c
+}
+
+// SetStream sets the "stream" field.
+func (_c *ConsumerCursorCreate) SetStream(v string) *ConsumerCursorCreate {
+	_c.mutation.SetStream(v)
+	return _c
+}
+
+// SetLastCreatedAt sets the "last_created_at" field.
+func (_c *ConsumerCursorCreate) SetLastCreatedAt(v time.Time) *ConsumerCursorCreate {
+	_c.mutation.SetLastCreatedAt(v)
+	return _c
+}
+
+// SetLastID sets the "last_id" field.
+func (_c *ConsumerCursorCreate) SetLastID(v int) *ConsumerCursorCreate {
+	_c.mutation.SetLastID(v)
+	return _c
+}
+
+// SetNillableLastID sets the "last_id" field if the given value is not nil.
+func (_c *ConsumerCursorCreate) SetNillableLastID(v *int) *ConsumerCursorCreate {
+	if v != nil {
+		_c.SetLastID(*v)
+	}
+	return _c
+}
+
+// SetRecent sets the "recent" field.
+func (_c *ConsumerCursorCreate) SetRecent(v map[int]time.Time) *ConsumerCursorCreate {
+	_c.mutation.SetRecent(v)
+	return _c
+}
+
+// SetRecentSince sets the "recent_since" field.
+func (_c *ConsumerCursorCreate) SetRecentSince(v time.Time) *ConsumerCursorCreate {
+	_c.mutation.SetRecentSince(v)
+	return _c
+}
+
+// SetNillableRecentSince sets the "recent_since" field if the given value is not nil.
+func (_c *ConsumerCursorCreate) SetNillableRecentSince(v *time.Time) *ConsumerCursorCreate {
+	if v != nil {
+		_c.SetRecentSince(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *ConsumerCursorCreate) SetUpdatedAt(v time.Time) *ConsumerCursorCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *ConsumerCursorCreate) SetNillableUpdatedAt(v *time.Time) *ConsumerCursorCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the ConsumerCursorMutation object of the builder.
+func (_c *ConsumerCursorCreate) Mutation() *ConsumerCursorMutation {
+	return _c.mutation
+}
+
+// Save creates the ConsumerCursor in the database.
+func (_c *ConsumerCursorCreate) Save(ctx context.Context) (*ConsumerCursor, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *ConsumerCursorCreate) SaveX(ctx context.Context) *ConsumerCursor {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ConsumerCursorCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ConsumerCursorCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *ConsumerCursorCreate) defaults() {
+	if _, ok := _c.mutation.LastID(); !ok {
+		v := consumercursor.DefaultLastID
+		_c.mutation.SetLastID(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := consumercursor.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *ConsumerCursorCreate) check() error {
+	if _, ok := _c.mutation.Consumer(); !ok {
+		return &ValidationError{Name: "consumer", err: errors.New(`ent: missing required field "ConsumerCursor.consumer"`)}
+	}
+	if _, ok := _c.mutation.Stream(); !ok {
+		return &ValidationError{Name: "stream", err: errors.New(`ent: missing required field "ConsumerCursor.stream"`)}
+	}
+	if _, ok := _c.mutation.LastCreatedAt(); !ok {
+		return &ValidationError{Name: "last_created_at", err: errors.New(`ent: missing required field "ConsumerCursor.last_created_at"`)}
+	}
+	if _, ok := _c.mutation.LastID(); !ok {
+		return &ValidationError{Name: "last_id", err: errors.New(`ent: missing required field "ConsumerCursor.last_id"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ConsumerCursor.updated_at"`)}
+	}
+	return nil
+}
+
+func (_c *ConsumerCursorCreate) sqlSave(ctx context.Context) (*ConsumerCursor, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *ConsumerCursorCreate) createSpec() (*ConsumerCursor, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ConsumerCursor{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(consumercursor.Table, sqlgraph.NewFieldSpec(consumercursor.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Consumer(); ok {
+		_spec.SetField(consumercursor.FieldConsumer, field.TypeString, value)
+		_node.Consumer = value
+	}
+	if value, ok := _c.mutation.Stream(); ok {
+		_spec.SetField(consumercursor.FieldStream, field.TypeString, value)
+		_node.Stream = value
+	}
+	if value, ok := _c.mutation.LastCreatedAt(); ok {
+		_spec.SetField(consumercursor.FieldLastCreatedAt, field.TypeTime, value)
+		_node.LastCreatedAt = value
+	}
+	if value, ok := _c.mutation.LastID(); ok {
+		_spec.SetField(consumercursor.FieldLastID, field.TypeInt, value)
+		_node.LastID = value
+	}
+	if value, ok := _c.mutation.Recent(); ok {
+		_spec.SetField(consumercursor.FieldRecent, field.TypeJSON, value)
+		_node.Recent = value
+	}
+	if value, ok := _c.mutation.RecentSince(); ok {
+		_spec.SetField(consumercursor.FieldRecentSince, field.TypeTime, value)
+		_node.RecentSince = &value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(consumercursor.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// ConsumerCursorCreateBulk is the builder for creating many ConsumerCursor entities in bulk.
+type ConsumerCursorCreateBulk struct {
+	config
+	err      error
+	builders []*ConsumerCursorCreate
+}
+
+// Save creates the ConsumerCursor entities in the database.
+func (_c *ConsumerCursorCreateBulk) Save(ctx context.Context) ([]*ConsumerCursor, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*ConsumerCursor, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ConsumerCursorMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *ConsumerCursorCreateBulk) SaveX(ctx context.Context) []*ConsumerCursor {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *ConsumerCursorCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *ConsumerCursorCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
+	"github.com/zeshi09/go_web_parser_agent/ent/predicate"
+)
+
+// ConsumerCursorDelete is the builder for deleting a ConsumerCursor entity.
+type ConsumerCursorDelete struct {
+	config
+	hooks    []Hook
+	mutation *ConsumerCursorMutation
+}
+
+// Where appends a list predicates to the ConsumerCursorDelete builder.
+func (_d *ConsumerCursorDelete) Where(ps ...predicate.ConsumerCursor) *ConsumerCursorDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *ConsumerCursorDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *ConsumerCursorDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *ConsumerCursorDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(consumercursor.Table, sqlgraph.NewFieldSpec(consumercursor.FieldID, field.TypeInt))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// ConsumerCursorDeleteOne is the builder for deleting a single ConsumerCursor entity.
+type ConsumerCursorDeleteOne struct {
+	_d *ConsumerCursorDelete
+}
+
+// Where appends a list predicates to the ConsumerCursorDelete builder.
+func (_d *ConsumerCursorDeleteOne) Where(ps ...predicate.ConsumerCursor) *ConsumerCursorDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *ConsumerCursorDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{consumercursor.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *ConsumerCursorDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,456 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
+	"github.com/zeshi09/go_web_parser_agent/ent/predicate"
+)
+
+// ConsumerCursorUpdate is the builder for updating ConsumerCursor entities.
+type ConsumerCursorUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ConsumerCursorMutation
+}
+
+// Where appends a list predicates to the ConsumerCursorUpdate builder.
+func (_u *ConsumerCursorUpdate) Where(ps ...predicate.ConsumerCursor) *ConsumerCursorUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetConsumer sets the "consumer" field.
+func (_u *ConsumerCursorUpdate) SetConsumer(v string) *ConsumerCursorUpdate {
+	_u.mutation.SetConsumer(v)
+	return _u
+}
+
+// SetNillableConsumer sets the "consumer" field if the given value is not nil.
+func (_u *ConsumerCursorUpdate) SetNillableConsumer(v *string) *ConsumerCursorUpdate {
+	if v != nil {
+		_u.SetConsumer(*v)
+	}
+	return _u
+}
+
+// SetStream sets the "stream" field.
+func (_u *ConsumerCursorUpdate) SetStream(v string) *ConsumerCursorUpdate {
+	_u.mutation.SetStream(v)
+	return _u
+}
+
+// SetNillableStream sets the "stream" field if the given value is not nil.
+func (_u *ConsumerCursorUpdate) SetNillableStream(v *string) *ConsumerCursorUpdate {
+	if v != nil {
+		_u.SetStream(*v)
+	}
+	return _u
+}
+
+// SetLastCreatedAt sets the "last_created_at" field.
+func (_u *ConsumerCursorUpdate) SetLastCreatedAt(v time.Time) *ConsumerCursorUpdate {
+	_u.mutation.SetLastCreatedAt(v)
+	return _u
+}
+
+// SetNillableLastCreatedAt sets the "last_created_at" field if the given value is not nil.
+func (_u *ConsumerCursorUpdate) SetNillableLastCreatedAt(v *time.Time) *ConsumerCursorUpdate {
+	if v != nil {
+		_u.SetLastCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetLastID sets the "last_id" field.
+func (_u *ConsumerCursorUpdate) SetLastID(v int) *ConsumerCursorUpdate {
+	_u.mutation.ResetLastID()
+	_u.mutation.SetLastID(v)
+	return _u
+}
+
+// SetNillableLastID sets the "last_id" field if the given value is not nil.
+func (_u *ConsumerCursorUpdate) SetNillableLastID(v *int) *ConsumerCursorUpdate {
+	if v != nil {
+		_u.SetLastID(*v)
+	}
+	return _u
+}
+
+// AddLastID adds value to the "last_id" field.
+func (_u *ConsumerCursorUpdate) AddLastID(v int) *ConsumerCursorUpdate {
+	_u.mutation.AddLastID(v)
+	return _u
+}
+
+// SetRecent sets the "recent" field.
+func (_u *ConsumerCursorUpdate) SetRecent(v map[int]time.Time) *ConsumerCursorUpdate {
+	_u.mutation.SetRecent(v)
+	return _u
+}
+
+// ClearRecent clears the value of the "recent" field.
+func (_u *ConsumerCursorUpdate) ClearRecent() *ConsumerCursorUpdate {
+	_u.mutation.ClearRecent()
+	return _u
+}
+
+// SetRecentSince sets the "recent_since" field.
+func (_u *ConsumerCursorUpdate) SetRecentSince(v time.Time) *ConsumerCursorUpdate {
+	_u.mutation.SetRecentSince(v)
+	return _u
+}
+
+// SetNillableRecentSince sets the "recent_since" field if the given value is not nil.
+func (_u *ConsumerCursorUpdate) SetNillableRecentSince(v *time.Time) *ConsumerCursorUpdate {
+	if v != nil {
+		_u.SetRecentSince(*v)
+	}
+	return _u
+}
+
+// ClearRecentSince clears the value of the "recent_since" field.
+func (_u *ConsumerCursorUpdate) ClearRecentSince() *ConsumerCursorUpdate {
+	_u.mutation.ClearRecentSince()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ConsumerCursorUpdate) SetUpdatedAt(v time.Time) *ConsumerCursorUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the ConsumerCursorMutation object of the builder.
+func (_u *ConsumerCursorUpdate) Mutation() *ConsumerCursorMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ConsumerCursorUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ConsumerCursorUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ConsumerCursorUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ConsumerCursorUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ConsumerCursorUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := consumercursor.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *ConsumerCursorUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(consumercursor.Table, consumercursor.Columns, sqlgraph.NewFieldSpec(consumercursor.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Consumer(); ok {
+		_spec.SetField(consumercursor.FieldConsumer, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Stream(); ok {
+		_spec.SetField(consumercursor.FieldStream, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastCreatedAt(); ok {
+		_spec.SetField(consumercursor.FieldLastCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.LastID(); ok {
+		_spec.SetField(consumercursor.FieldLastID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLastID(); ok {
+		_spec.AddField(consumercursor.FieldLastID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Recent(); ok {
+		_spec.SetField(consumercursor.FieldRecent, field.TypeJSON, value)
+	}
+	if _u.mutation.RecentCleared() {
+		_spec.ClearField(consumercursor.FieldRecent, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RecentSince(); ok {
+		_spec.SetField(consumercursor.FieldRecentSince, field.TypeTime, value)
+	}
+	if _u.mutation.RecentSinceCleared() {
+		_spec.ClearField(consumercursor.FieldRecentSince, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(consumercursor.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consumercursor.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ConsumerCursorUpdateOne is the builder for updating a single ConsumerCursor entity.
+type ConsumerCursorUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ConsumerCursorMutation
+}
+
+// SetConsumer sets the "consumer" field.
+func (_u *ConsumerCursorUpdateOne) SetConsumer(v string) *ConsumerCursorUpdateOne {
+	_u.mutation.SetConsumer(v)
+	return _u
+}
+
+// SetNillableConsumer sets the "consumer" field if the given value is not nil.
+func (_u *ConsumerCursorUpdateOne) SetNillableConsumer(v *string) *ConsumerCursorUpdateOne {
+	if v != nil {
+		_u.SetConsumer(*v)
+	}
+	return _u
+}
+
+// SetStream sets the "stream" field.
+func (_u *ConsumerCursorUpdateOne) SetStream(v string) *ConsumerCursorUpdateOne {
+	_u.mutation.SetStream(v)
+	return _u
+}
+
+// SetNillableStream sets the "stream" field if the given value is not nil.
+func (_u *ConsumerCursorUpdateOne) SetNillableStream(v *string) *ConsumerCursorUpdateOne {
+	if v != nil {
+		_u.SetStream(*v)
+	}
+	return _u
+}
+
+// SetLastCreatedAt sets the "last_created_at" field.
+func (_u *ConsumerCursorUpdateOne) SetLastCreatedAt(v time.Time) *ConsumerCursorUpdateOne {
+	_u.mutation.SetLastCreatedAt(v)
+	return _u
+}
+
+// SetNillableLastCreatedAt sets the "last_created_at" field if the given value is not nil.
+func (_u *ConsumerCursorUpdateOne) SetNillableLastCreatedAt(v *time.Time) *ConsumerCursorUpdateOne {
+	if v != nil {
+		_u.SetLastCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetLastID sets the "last_id" field.
+func (_u *ConsumerCursorUpdateOne) SetLastID(v int) *ConsumerCursorUpdateOne {
+	_u.mutation.ResetLastID()
+	_u.mutation.SetLastID(v)
+	return _u
+}
+
+// SetNillableLastID sets the "last_id" field if the given value is not nil.
+func (_u *ConsumerCursorUpdateOne) SetNillableLastID(v *int) *ConsumerCursorUpdateOne {
+	if v != nil {
+		_u.SetLastID(*v)
+	}
+	return _u
+}
+
+// AddLastID adds value to the "last_id" field.
+func (_u *ConsumerCursorUpdateOne) AddLastID(v int) *ConsumerCursorUpdateOne {
+	_u.mutation.AddLastID(v)
+	return _u
+}
+
+// SetRecent sets the "recent" field.
+func (_u *ConsumerCursorUpdateOne) SetRecent(v map[int]time.Time) *ConsumerCursorUpdateOne {
+	_u.mutation.SetRecent(v)
+	return _u
+}
+
+// ClearRecent clears the value of the "recent" field.
+func (_u *ConsumerCursorUpdateOne) ClearRecent() *ConsumerCursorUpdateOne {
+	_u.mutation.ClearRecent()
+	return _u
+}
+
+// SetRecentSince sets the "recent_since" field.
+func (_u *ConsumerCursorUpdateOne) SetRecentSince(v time.Time) *ConsumerCursorUpdateOne {
+	_u.mutation.SetRecentSince(v)
+	return _u
+}
+
+// SetNillableRecentSince sets the "recent_since" field if the given value is not nil.
+func (_u *ConsumerCursorUpdateOne) SetNillableRecentSince(v *time.Time) *ConsumerCursorUpdateOne {
+	if v != nil {
+		_u.SetRecentSince(*v)
+	}
+	return _u
+}
+
+// ClearRecentSince clears the value of the "recent_since" field.
+func (_u *ConsumerCursorUpdateOne) ClearRecentSince() *ConsumerCursorUpdateOne {
+	_u.mutation.ClearRecentSince()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *ConsumerCursorUpdateOne) SetUpdatedAt(v time.Time) *ConsumerCursorUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// Mutation returns the ConsumerCursorMutation object of the builder.
+func (_u *ConsumerCursorUpdateOne) Mutation() *ConsumerCursorMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the ConsumerCursorUpdate builder.
+func (_u *ConsumerCursorUpdateOne) Where(ps ...predicate.ConsumerCursor) *ConsumerCursorUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ConsumerCursorUpdateOne) Select(field string, fields ...string) *ConsumerCursorUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ConsumerCursor entity.
+func (_u *ConsumerCursorUpdateOne) Save(ctx context.Context) (*ConsumerCursor, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ConsumerCursorUpdateOne) SaveX(ctx context.Context) *ConsumerCursor {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ConsumerCursorUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ConsumerCursorUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *ConsumerCursorUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := consumercursor.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *ConsumerCursorUpdateOne) sqlSave(ctx context.Context) (_node *ConsumerCursor, err error) {
+	_spec := sqlgraph.NewUpdateSpec(consumercursor.Table, consumercursor.Columns, sqlgraph.NewFieldSpec(consumercursor.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ConsumerCursor.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, consumercursor.FieldID)
+		for _, f := range fields {
+			if !consumercursor.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != consumercursor.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Consumer(); ok {
+		_spec.SetField(consumercursor.FieldConsumer, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Stream(); ok {
+		_spec.SetField(consumercursor.FieldStream, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastCreatedAt(); ok {
+		_spec.SetField(consumercursor.FieldLastCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.LastID(); ok {
+		_spec.SetField(consumercursor.FieldLastID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedLastID(); ok {
+		_spec.AddField(consumercursor.FieldLastID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Recent(); ok {
+		_spec.SetField(consumercursor.FieldRecent, field.TypeJSON, value)
+	}
+	if _u.mutation.RecentCleared() {
+		_spec.ClearField(consumercursor.FieldRecent, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RecentSince(); ok {
+		_spec.SetField(consumercursor.FieldRecentSince, field.TypeTime, value)
+	}
+	if _u.mutation.RecentSinceCleared() {
+		_spec.ClearField(consumercursor.FieldRecentSince, field.TypeTime)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(consumercursor.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &ConsumerCursor{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consumercursor.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -12,7 +12,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
 	"github.com/zeshi09/go_web_parser_agent/ent/domain"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
 	"github.com/zeshi09/go_web_parser_agent/ent/sociallink"
 )
 
@@ -74,8 +76,10 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			domain.Table:     domain.ValidColumn,
-			sociallink.Table: sociallink.ValidColumn,
+			consumercursor.Table: consumercursor.ValidColumn,
+			domain.Table:         domain.ValidColumn,
+			outbox.Table:         outbox.ValidColumn,
+			sociallink.Table:     sociallink.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
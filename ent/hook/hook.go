@@ -9,6 +9,18 @@ import (
 	"github.com/zeshi09/go_web_parser_agent/ent"
 )
 
+// The ConsumerCursorFunc type is an adapter to allow the use of ordinary
+// function as ConsumerCursor mutator.
+type ConsumerCursorFunc func(context.Context, *ent.ConsumerCursorMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ConsumerCursorFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.ConsumerCursorMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ConsumerCursorMutation", m)
+}
+
 // The DomainFunc type is an adapter to allow the use of ordinary
 // function as Domain mutator.
 type DomainFunc func(context.Context, *ent.DomainMutation) (ent.Value, error)
@@ -21,6 +33,18 @@ func (f DomainFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, erro
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.DomainMutation", m)
 }
 
+// The OutboxFunc type is an adapter to allow the use of ordinary
+// function as Outbox mutator.
+type OutboxFunc func(context.Context, *ent.OutboxMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f OutboxFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.OutboxMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.OutboxMutation", m)
+}
+
 // The SocialLinkFunc type is an adapter to allow the use of ordinary
 // function as SocialLink mutator.
 type SocialLinkFunc func(context.Context, *ent.SocialLinkMutation) (ent.Value, error)
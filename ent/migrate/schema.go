@@ -3,11 +3,36 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// ConsumerCursorsColumns holds the columns for the "consumer_cursors" table.
+	ConsumerCursorsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "consumer", Type: field.TypeString},
+		{Name: "stream", Type: field.TypeString},
+		{Name: "last_created_at", Type: field.TypeTime},
+		{Name: "last_id", Type: field.TypeInt, Default: 0},
+		{Name: "recent", Type: field.TypeJSON, Nullable: true},
+		{Name: "recent_since", Type: field.TypeTime, Nullable: true},
+		{Name: "updated_at", Type: field.TypeTime},
+	}
+	// ConsumerCursorsTable holds the schema information for the "consumer_cursors" table.
+	ConsumerCursorsTable = &schema.Table{
+		Name:       "consumer_cursors",
+		Columns:    ConsumerCursorsColumns,
+		PrimaryKey: []*schema.Column{ConsumerCursorsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "consumercursor_consumer_stream",
+				Unique:  true,
+				Columns: []*schema.Column{ConsumerCursorsColumns[1], ConsumerCursorsColumns[2]},
+			},
+		},
+	}
 	// DomainsColumns holds the columns for the "domains" table.
 	DomainsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -27,6 +52,40 @@ var (
 			},
 		},
 	}
+	// OutboxColumns holds the columns for the "outbox" table.
+	OutboxColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "consumer", Type: field.TypeString},
+		{Name: "sink", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeEnum, Enums: []string{"domain", "social_link"}},
+		{Name: "entity_id", Type: field.TypeInt},
+		{Name: "key", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeString, Size: 2147483647, SchemaType: map[string]string{"postgres": "jsonb"}},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "delivered", "failed"}, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "delivered_at", Type: field.TypeTime, Nullable: true},
+	}
+	// OutboxTable holds the schema information for the "outbox" table.
+	OutboxTable = &schema.Table{
+		Name:       "outbox",
+		Columns:    OutboxColumns,
+		PrimaryKey: []*schema.Column{OutboxColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outbox_consumer_sink_key",
+				Unique:  true,
+				Columns: []*schema.Column{OutboxColumns[1], OutboxColumns[2], OutboxColumns[5]},
+			},
+			{
+				Name:    "outbox_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxColumns[7], OutboxColumns[9]},
+			},
+		},
+	}
 	// SocialLinksColumns holds the columns for the "social_links" table.
 	SocialLinksColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -65,10 +124,15 @@ var (
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		ConsumerCursorsTable,
 		DomainsTable,
+		OutboxTable,
 		SocialLinksTable,
 	}
 )
 
 func init() {
+	OutboxTable.Annotation = &entsql.Annotation{
+		Table: "outbox",
+	}
 }
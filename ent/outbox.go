@@ -0,0 +1,230 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
+)
+
+// Outbox is the model entity for the Outbox schema.
+type Outbox struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Consumer that enqueued the notification
+	Consumer string `json:"consumer,omitempty"`
+	// Sink the notification is addressed to
+	Sink string `json:"sink,omitempty"`
+	// Entity type of the payload
+	Kind outbox.Kind `json:"kind,omitempty"`
+	// ID of the domain or social link row
+	EntityID int `json:"entity_id,omitempty"`
+	// Idempotency key, unique per consumer and sink
+	Key string `json:"key,omitempty"`
+	// JSON snapshot of the row at enqueue time
+	Payload string `json:"payload,omitempty"`
+	// Delivery status
+	Status outbox.Status `json:"status,omitempty"`
+	// Number of failed delivery attempts
+	Attempts int `json:"attempts,omitempty"`
+	// Earliest time of the next delivery attempt
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+	// Error of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+	// When the notification was enqueued
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// When the sink accepted the notification
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Outbox) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case outbox.FieldID, outbox.FieldEntityID, outbox.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case outbox.FieldConsumer, outbox.FieldSink, outbox.FieldKind, outbox.FieldKey, outbox.FieldPayload, outbox.FieldStatus, outbox.FieldLastError:
+			values[i] = new(sql.NullString)
+		case outbox.FieldNextAttemptAt, outbox.FieldCreatedAt, outbox.FieldDeliveredAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Outbox fields.
+func (_m *Outbox) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case outbox.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case outbox.FieldConsumer:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field consumer", values[i])
+			} else if value.Valid {
+				_m.Consumer = value.String
+			}
+		case outbox.FieldSink:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field sink", values[i])
+			} else if value.Valid {
+				_m.Sink = value.String
+			}
+		case outbox.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				_m.Kind = outbox.Kind(value.String)
+			}
+		case outbox.FieldEntityID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field entity_id", values[i])
+			} else if value.Valid {
+				_m.EntityID = int(value.Int64)
+			}
+		case outbox.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				_m.Key = value.String
+			}
+		case outbox.FieldPayload:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field payload", values[i])
+			} else if value.Valid {
+				_m.Payload = value.String
+			}
+		case outbox.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = outbox.Status(value.String)
+			}
+		case outbox.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				_m.Attempts = int(value.Int64)
+			}
+		case outbox.FieldNextAttemptAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field next_attempt_at", values[i])
+			} else if value.Valid {
+				_m.NextAttemptAt = value.Time
+			}
+		case outbox.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				_m.LastError = value.String
+			}
+		case outbox.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case outbox.FieldDeliveredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field delivered_at", values[i])
+			} else if value.Valid {
+				_m.DeliveredAt = new(time.Time)
+				*_m.DeliveredAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Outbox.
+// This includes values selected through modifiers, order, etc.
+func (_m *Outbox) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Outbox.
+// Note that you need to call Outbox.Unwrap() before calling this method if this Outbox
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Outbox) Update() *OutboxUpdateOne {
+	return NewOutboxClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Outbox entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Outbox) Unwrap() *Outbox {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Outbox is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Outbox) String() string {
+	var builder strings.Builder
+	builder.WriteString("Outbox(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("consumer=")
+	builder.WriteString(_m.Consumer)
+	builder.WriteString(", ")
+	builder.WriteString("sink=")
+	builder.WriteString(_m.Sink)
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("entity_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EntityID))
+	builder.WriteString(", ")
+	builder.WriteString("key=")
+	builder.WriteString(_m.Key)
+	builder.WriteString(", ")
+	builder.WriteString("payload=")
+	builder.WriteString(_m.Payload)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Status))
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("next_attempt_at=")
+	builder.WriteString(_m.NextAttemptAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(_m.LastError)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.DeliveredAt; v != nil {
+		builder.WriteString("delivered_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Outboxes is a parsable slice of Outbox.
+type Outboxes []*Outbox
@@ -0,0 +1,696 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
+	"github.com/zeshi09/go_web_parser_agent/ent/predicate"
+)
+
+// OutboxUpdate is the builder for updating Outbox entities.
+type OutboxUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// Where appends a list predicates to the OutboxUpdate builder.
+func (_u *OutboxUpdate) Where(ps ...predicate.Outbox) *OutboxUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetConsumer sets the "consumer" field.
+func (_u *OutboxUpdate) SetConsumer(v string) *OutboxUpdate {
+	_u.mutation.SetConsumer(v)
+	return _u
+}
+
+// SetNillableConsumer sets the "consumer" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableConsumer(v *string) *OutboxUpdate {
+	if v != nil {
+		_u.SetConsumer(*v)
+	}
+	return _u
+}
+
+// SetSink sets the "sink" field.
+func (_u *OutboxUpdate) SetSink(v string) *OutboxUpdate {
+	_u.mutation.SetSink(v)
+	return _u
+}
+
+// SetNillableSink sets the "sink" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableSink(v *string) *OutboxUpdate {
+	if v != nil {
+		_u.SetSink(*v)
+	}
+	return _u
+}
+
+// SetKind sets the "kind" field.
+func (_u *OutboxUpdate) SetKind(v outbox.Kind) *OutboxUpdate {
+	_u.mutation.SetKind(v)
+	return _u
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableKind(v *outbox.Kind) *OutboxUpdate {
+	if v != nil {
+		_u.SetKind(*v)
+	}
+	return _u
+}
+
+// SetEntityID sets the "entity_id" field.
+func (_u *OutboxUpdate) SetEntityID(v int) *OutboxUpdate {
+	_u.mutation.ResetEntityID()
+	_u.mutation.SetEntityID(v)
+	return _u
+}
+
+// SetNillableEntityID sets the "entity_id" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableEntityID(v *int) *OutboxUpdate {
+	if v != nil {
+		_u.SetEntityID(*v)
+	}
+	return _u
+}
+
+// AddEntityID adds value to the "entity_id" field.
+func (_u *OutboxUpdate) AddEntityID(v int) *OutboxUpdate {
+	_u.mutation.AddEntityID(v)
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *OutboxUpdate) SetKey(v string) *OutboxUpdate {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableKey(v *string) *OutboxUpdate {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetPayload sets the "payload" field.
+func (_u *OutboxUpdate) SetPayload(v string) *OutboxUpdate {
+	_u.mutation.SetPayload(v)
+	return _u
+}
+
+// SetNillablePayload sets the "payload" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillablePayload(v *string) *OutboxUpdate {
+	if v != nil {
+		_u.SetPayload(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *OutboxUpdate) SetStatus(v outbox.Status) *OutboxUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableStatus(v *outbox.Status) *OutboxUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *OutboxUpdate) SetAttempts(v int) *OutboxUpdate {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableAttempts(v *int) *OutboxUpdate {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *OutboxUpdate) AddAttempts(v int) *OutboxUpdate {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (_u *OutboxUpdate) SetNextAttemptAt(v time.Time) *OutboxUpdate {
+	_u.mutation.SetNextAttemptAt(v)
+	return _u
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableNextAttemptAt(v *time.Time) *OutboxUpdate {
+	if v != nil {
+		_u.SetNextAttemptAt(*v)
+	}
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *OutboxUpdate) SetLastError(v string) *OutboxUpdate {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableLastError(v *string) *OutboxUpdate {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (_u *OutboxUpdate) ClearLastError() *OutboxUpdate {
+	_u.mutation.ClearLastError()
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *OutboxUpdate) SetCreatedAt(v time.Time) *OutboxUpdate {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableCreatedAt(v *time.Time) *OutboxUpdate {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (_u *OutboxUpdate) SetDeliveredAt(v time.Time) *OutboxUpdate {
+	_u.mutation.SetDeliveredAt(v)
+	return _u
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (_u *OutboxUpdate) SetNillableDeliveredAt(v *time.Time) *OutboxUpdate {
+	if v != nil {
+		_u.SetDeliveredAt(*v)
+	}
+	return _u
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (_u *OutboxUpdate) ClearDeliveredAt() *OutboxUpdate {
+	_u.mutation.ClearDeliveredAt()
+	return _u
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (_u *OutboxUpdate) Mutation() *OutboxMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *OutboxUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *OutboxUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *OutboxUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *OutboxUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *OutboxUpdate) check() error {
+	if v, ok := _u.mutation.Kind(); ok {
+		if err := outbox.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Outbox.kind": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := outbox.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Outbox.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *OutboxUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(outbox.Table, outbox.Columns, sqlgraph.NewFieldSpec(outbox.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Consumer(); ok {
+		_spec.SetField(outbox.FieldConsumer, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Sink(); ok {
+		_spec.SetField(outbox.FieldSink, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Kind(); ok {
+		_spec.SetField(outbox.FieldKind, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.EntityID(); ok {
+		_spec.SetField(outbox.FieldEntityID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedEntityID(); ok {
+		_spec.AddField(outbox.FieldEntityID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(outbox.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Payload(); ok {
+		_spec.SetField(outbox.FieldPayload, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(outbox.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outbox.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(outbox.FieldLastError, field.TypeString, value)
+	}
+	if _u.mutation.LastErrorCleared() {
+		_spec.ClearField(outbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(outbox.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeliveredAt(); ok {
+		_spec.SetField(outbox.FieldDeliveredAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeliveredAtCleared() {
+		_spec.ClearField(outbox.FieldDeliveredAt, field.TypeTime)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// OutboxUpdateOne is the builder for updating a single Outbox entity.
+type OutboxUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OutboxMutation
+}
+
+// SetConsumer sets the "consumer" field.
+func (_u *OutboxUpdateOne) SetConsumer(v string) *OutboxUpdateOne {
+	_u.mutation.SetConsumer(v)
+	return _u
+}
+
+// SetNillableConsumer sets the "consumer" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableConsumer(v *string) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetConsumer(*v)
+	}
+	return _u
+}
+
+// SetSink sets the "sink" field.
+func (_u *OutboxUpdateOne) SetSink(v string) *OutboxUpdateOne {
+	_u.mutation.SetSink(v)
+	return _u
+}
+
+// SetNillableSink sets the "sink" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableSink(v *string) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetSink(*v)
+	}
+	return _u
+}
+
+// SetKind sets the "kind" field.
+func (_u *OutboxUpdateOne) SetKind(v outbox.Kind) *OutboxUpdateOne {
+	_u.mutation.SetKind(v)
+	return _u
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableKind(v *outbox.Kind) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetKind(*v)
+	}
+	return _u
+}
+
+// SetEntityID sets the "entity_id" field.
+func (_u *OutboxUpdateOne) SetEntityID(v int) *OutboxUpdateOne {
+	_u.mutation.ResetEntityID()
+	_u.mutation.SetEntityID(v)
+	return _u
+}
+
+// SetNillableEntityID sets the "entity_id" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableEntityID(v *int) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetEntityID(*v)
+	}
+	return _u
+}
+
+// AddEntityID adds value to the "entity_id" field.
+func (_u *OutboxUpdateOne) AddEntityID(v int) *OutboxUpdateOne {
+	_u.mutation.AddEntityID(v)
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *OutboxUpdateOne) SetKey(v string) *OutboxUpdateOne {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableKey(v *string) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetPayload sets the "payload" field.
+func (_u *OutboxUpdateOne) SetPayload(v string) *OutboxUpdateOne {
+	_u.mutation.SetPayload(v)
+	return _u
+}
+
+// SetNillablePayload sets the "payload" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillablePayload(v *string) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetPayload(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *OutboxUpdateOne) SetStatus(v outbox.Status) *OutboxUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableStatus(v *outbox.Status) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *OutboxUpdateOne) SetAttempts(v int) *OutboxUpdateOne {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableAttempts(v *int) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *OutboxUpdateOne) AddAttempts(v int) *OutboxUpdateOne {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (_u *OutboxUpdateOne) SetNextAttemptAt(v time.Time) *OutboxUpdateOne {
+	_u.mutation.SetNextAttemptAt(v)
+	return _u
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableNextAttemptAt(v *time.Time) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetNextAttemptAt(*v)
+	}
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *OutboxUpdateOne) SetLastError(v string) *OutboxUpdateOne {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableLastError(v *string) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (_u *OutboxUpdateOne) ClearLastError() *OutboxUpdateOne {
+	_u.mutation.ClearLastError()
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *OutboxUpdateOne) SetCreatedAt(v time.Time) *OutboxUpdateOne {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableCreatedAt(v *time.Time) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (_u *OutboxUpdateOne) SetDeliveredAt(v time.Time) *OutboxUpdateOne {
+	_u.mutation.SetDeliveredAt(v)
+	return _u
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (_u *OutboxUpdateOne) SetNillableDeliveredAt(v *time.Time) *OutboxUpdateOne {
+	if v != nil {
+		_u.SetDeliveredAt(*v)
+	}
+	return _u
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (_u *OutboxUpdateOne) ClearDeliveredAt() *OutboxUpdateOne {
+	_u.mutation.ClearDeliveredAt()
+	return _u
+}
+
+// Mutation returns the OutboxMutation object of the builder.
+func (_u *OutboxUpdateOne) Mutation() *OutboxMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the OutboxUpdate builder.
+func (_u *OutboxUpdateOne) Where(ps ...predicate.Outbox) *OutboxUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *OutboxUpdateOne) Select(field string, fields ...string) *OutboxUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Outbox entity.
+func (_u *OutboxUpdateOne) Save(ctx context.Context) (*Outbox, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *OutboxUpdateOne) SaveX(ctx context.Context) *Outbox {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *OutboxUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *OutboxUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *OutboxUpdateOne) check() error {
+	if v, ok := _u.mutation.Kind(); ok {
+		if err := outbox.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Outbox.kind": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := outbox.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Outbox.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *OutboxUpdateOne) sqlSave(ctx context.Context) (_node *Outbox, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(outbox.Table, outbox.Columns, sqlgraph.NewFieldSpec(outbox.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Outbox.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, outbox.FieldID)
+		for _, f := range fields {
+			if !outbox.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != outbox.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Consumer(); ok {
+		_spec.SetField(outbox.FieldConsumer, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Sink(); ok {
+		_spec.SetField(outbox.FieldSink, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Kind(); ok {
+		_spec.SetField(outbox.FieldKind, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.EntityID(); ok {
+		_spec.SetField(outbox.FieldEntityID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedEntityID(); ok {
+		_spec.AddField(outbox.FieldEntityID, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(outbox.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Payload(); ok {
+		_spec.SetField(outbox.FieldPayload, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(outbox.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(outbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outbox.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(outbox.FieldLastError, field.TypeString, value)
+	}
+	if _u.mutation.LastErrorCleared() {
+		_spec.ClearField(outbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(outbox.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeliveredAt(); ok {
+		_spec.SetField(outbox.FieldDeliveredAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeliveredAtCleared() {
+		_spec.ClearField(outbox.FieldDeliveredAt, field.TypeTime)
+	}
+	_node = &Outbox{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,8 +6,14 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// ConsumerCursor is the predicate function for consumercursor builders.
+type ConsumerCursor func(*sql.Selector)
+
 // Domain is the predicate function for domain builders.
 type Domain func(*sql.Selector)
 
+// Outbox is the predicate function for outbox builders.
+type Outbox func(*sql.Selector)
+
 // SocialLink is the predicate function for sociallink builders.
 type SocialLink func(*sql.Selector)
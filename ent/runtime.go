@@ -5,7 +5,9 @@ package ent
 import (
 	"time"
 
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
 	"github.com/zeshi09/go_web_parser_agent/ent/domain"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
 	"github.com/zeshi09/go_web_parser_agent/ent/schema"
 	"github.com/zeshi09/go_web_parser_agent/ent/sociallink"
 )
@@ -14,12 +16,38 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	consumercursorFields := schema.ConsumerCursor{}.Fields()
+	_ = consumercursorFields
+	// consumercursorDescLastID is the schema descriptor for last_id field.
+	consumercursorDescLastID := consumercursorFields[3].Descriptor()
+	// consumercursor.DefaultLastID holds the default value on creation for the last_id field.
+	consumercursor.DefaultLastID = consumercursorDescLastID.Default.(int)
+	// consumercursorDescUpdatedAt is the schema descriptor for updated_at field.
+	consumercursorDescUpdatedAt := consumercursorFields[6].Descriptor()
+	// consumercursor.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	consumercursor.DefaultUpdatedAt = consumercursorDescUpdatedAt.Default.(func() time.Time)
+	// consumercursor.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	consumercursor.UpdateDefaultUpdatedAt = consumercursorDescUpdatedAt.UpdateDefault.(func() time.Time)
 	domainFields := schema.Domain{}.Fields()
 	_ = domainFields
 	// domainDescCreatedAt is the schema descriptor for created_at field.
 	domainDescCreatedAt := domainFields[1].Descriptor()
 	// domain.DefaultCreatedAt holds the default value on creation for the created_at field.
 	domain.DefaultCreatedAt = domainDescCreatedAt.Default.(func() time.Time)
+	outboxFields := schema.Outbox{}.Fields()
+	_ = outboxFields
+	// outboxDescAttempts is the schema descriptor for attempts field.
+	outboxDescAttempts := outboxFields[7].Descriptor()
+	// outbox.DefaultAttempts holds the default value on creation for the attempts field.
+	outbox.DefaultAttempts = outboxDescAttempts.Default.(int)
+	// outboxDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	outboxDescNextAttemptAt := outboxFields[8].Descriptor()
+	// outbox.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	outbox.DefaultNextAttemptAt = outboxDescNextAttemptAt.Default.(func() time.Time)
+	// outboxDescCreatedAt is the schema descriptor for created_at field.
+	outboxDescCreatedAt := outboxFields[10].Descriptor()
+	// outbox.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outbox.DefaultCreatedAt = outboxDescCreatedAt.Default.(func() time.Time)
 	sociallinkFields := schema.SocialLink{}.Fields()
 	_ = sociallinkFields
 	// sociallinkDescCreatedAt is the schema descriptor for created_at field.
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ConsumerCursor holds the schema definition for the ConsumerCursor entity.
+type ConsumerCursor struct {
+	ent.Schema
+}
+
+// Fields of the ConsumerCursor.
+func (ConsumerCursor) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("consumer").
+			Comment("Consumer name owning this cursor"),
+		field.String("stream").
+			Comment("Watched table (domains, social_links)"),
+		field.Time("last_created_at").
+			Comment("created_at of the last processed row"),
+		field.Int("last_id").
+			Default(0).
+			Comment("ID of the last processed row"),
+		field.JSON("recent", map[int]time.Time{}).
+			Optional().
+			Comment("IDs already processed inside the look-back window"),
+		field.Time("recent_since").
+			Optional().
+			Nillable().
+			Comment("Start of the look-back window after recent was trimmed to its cap"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("When the cursor was last moved"),
+	}
+}
+
+// Edges of the ConsumerCursor.
+func (ConsumerCursor) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the ConsumerCursor.
+func (ConsumerCursor) Indexes() []ent.Index {
+	return []ent.Index{
+		// один курсор на пару потребитель/таблица
+		index.Fields("consumer", "stream").Unique(),
+	}
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Outbox holds the schema definition for the Outbox entity.
+type Outbox struct {
+	ent.Schema
+}
+
+// Annotations of the Outbox.
+func (Outbox) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "outbox"},
+	}
+}
+
+// Fields of the Outbox.
+func (Outbox) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("consumer").
+			Comment("Consumer that enqueued the notification"),
+		field.String("sink").
+			Comment("Sink the notification is addressed to"),
+		field.Enum("kind").
+			Values("domain", "social_link").
+			Comment("Entity type of the payload"),
+		field.Int("entity_id").
+			Comment("ID of the domain or social link row"),
+		field.String("key").
+			Comment("Idempotency key, unique per consumer and sink"),
+		field.Text("payload").
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("JSON snapshot of the row at enqueue time"),
+		field.Enum("status").
+			Values("pending", "delivered", "failed").
+			Default("pending").
+			Comment("Delivery status"),
+		field.Int("attempts").
+			Default(0).
+			Comment("Number of failed delivery attempts"),
+		field.Time("next_attempt_at").
+			Default(time.Now).
+			Comment("Earliest time of the next delivery attempt"),
+		field.String("last_error").
+			Optional().
+			Comment("Error of the last failed attempt"),
+		field.Time("created_at").
+			Default(time.Now).
+			Comment("When the notification was enqueued"),
+		field.Time("delivered_at").
+			Optional().
+			Nillable().
+			Comment("When the sink accepted the notification"),
+	}
+}
+
+// Edges of the Outbox.
+func (Outbox) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the Outbox.
+func (Outbox) Indexes() []ent.Index {
+	return []ent.Index{
+		// ключ идемпотентности: одна строка — одна доставка в каждый sink
+		index.Fields("consumer", "sink", "key").Unique(),
+		// выборка диспетчером готовых к отправке записей
+		index.Fields("status", "next_attempt_at"),
+	}
+}
@@ -12,8 +12,12 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// ConsumerCursor is the client for interacting with the ConsumerCursor builders.
+	ConsumerCursor *ConsumerCursorClient
 	// Domain is the client for interacting with the Domain builders.
 	Domain *DomainClient
+	// Outbox is the client for interacting with the Outbox builders.
+	Outbox *OutboxClient
 	// SocialLink is the client for interacting with the SocialLink builders.
 	SocialLink *SocialLinkClient
 
@@ -147,7 +151,9 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.ConsumerCursor = NewConsumerCursorClient(tx.config)
 	tx.Domain = NewDomainClient(tx.config)
+	tx.Outbox = NewOutboxClient(tx.config)
 	tx.SocialLink = NewSocialLinkClient(tx.config)
 }
 
@@ -158,7 +164,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Domain.QueryXXX(), the query will be executed
+// applies a query, for example: ConsumerCursor.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
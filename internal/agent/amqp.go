@@ -0,0 +1,195 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog/log"
+)
+
+// AMQP публикует события в topic exchange RabbitMQ с routing key domain.new / link.new.<платформа>.
+// Канал работает в режиме publisher confirms: пачка считается доставленной, только когда брокер
+// подтвердил каждое сообщение. Сообщения публикуются с mandatory: если ни одна очередь не привязана
+// к routing key, брокер возвращает сообщение (basic.return) и подтверждает его, и такая пачка
+// считается недоставленной. Потерянное соединение переоткрывается при следующей публикации
+type AMQP struct {
+	URL      string
+	Exchange string
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	returns chan amqp.Return
+}
+
+// сколько сообщений публикуется до ожидания подтверждений; буфер возвратов не меньше,
+// чтобы библиотека не блокировалась на записи в него, пока мы ждём confirms
+const amqpChunkSize = 1000
+
+func NewAMQP(url, exchange string) (*AMQP, error) {
+	a := &AMQP{URL: url, Exchange: exchange}
+	if _, err := a.channel(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *AMQP) Name() string {
+	return "amqp"
+}
+
+func (a *AMQP) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reset()
+	return nil
+}
+
+func (a *AMQP) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	msgs := make([]amqpMessage, 0, len(events))
+	for _, d := range events {
+		msgs = append(msgs, amqpMessage{key: "domain.new", env: domainEnvelope(d)})
+	}
+	return a.publish(ctx, msgs)
+}
+
+func (a *AMQP) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	msgs := make([]amqpMessage, 0, len(events))
+	for _, l := range events {
+		msgs = append(msgs, amqpMessage{key: "link.new." + routingToken(l.PlatformName()), env: linkEnvelope(l)})
+	}
+	return a.publish(ctx, msgs)
+}
+
+type amqpMessage struct {
+	key string
+	env envelope
+}
+
+// publish делает одну повторную попытку на свежем соединении, если канал оказался закрыт
+func (a *AMQP) publish(ctx context.Context, msgs []amqpMessage) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	err := a.publishOnce(ctx, msgs)
+	if err == nil || !errors.Is(err, amqp.ErrClosed) {
+		return err
+	}
+	log.Warn().Err(err).Msg("amqp channel closed, reconnecting")
+	a.reset()
+	return a.publishOnce(ctx, msgs)
+}
+
+func (a *AMQP) publishOnce(ctx context.Context, msgs []amqpMessage) error {
+	for start := 0; start < len(msgs); start += amqpChunkSize {
+		if err := a.publishChunk(ctx, msgs[start:min(start+amqpChunkSize, len(msgs))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *AMQP) publishChunk(ctx context.Context, msgs []amqpMessage) error {
+	ch, err := a.channel()
+	if err != nil {
+		return err
+	}
+	// возвраты от прошлой неудачной пачки к этой не относятся
+	a.drainReturns()
+
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(msgs))
+	for _, m := range msgs {
+		body, err := json.Marshal(m.env)
+		if err != nil {
+			return err
+		}
+		dc, err := ch.PublishWithDeferredConfirmWithContext(ctx, a.Exchange, m.key, true, false, amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    m.env.ID,
+			Type:         m.env.Type,
+			Timestamp:    time.Now(),
+			Body:         body,
+		})
+		if err != nil {
+			return fmt.Errorf("publish %s: %w", m.key, err)
+		}
+		confirms = append(confirms, dc)
+	}
+
+	for i, dc := range confirms {
+		ok, err := dc.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("amqp broker nacked %s", msgs[i].env.ID)
+		}
+	}
+
+	// брокер шлёт basic.return раньше basic.ack, так что после всех подтверждений возвраты уже в канале
+	if returned := a.drainReturns(); len(returned) > 0 {
+		r := returned[0]
+		return fmt.Errorf("amqp broker returned %d unroutable messages, first %s with key %q: %d %s",
+			len(returned), r.MessageId, r.RoutingKey, r.ReplyCode, r.ReplyText)
+	}
+	return nil
+}
+
+// drainReturns забирает накопившиеся возвраты, не блокируясь
+func (a *AMQP) drainReturns() []amqp.Return {
+	var out []amqp.Return
+	for {
+		select {
+		case r, ok := <-a.returns:
+			if !ok {
+				return out
+			}
+			out = append(out, r)
+		default:
+			return out
+		}
+	}
+}
+
+// channel возвращает открытый канал в режиме confirms, при необходимости переподключаясь
+func (a *AMQP) channel() (*amqp.Channel, error) {
+	if a.ch != nil && !a.ch.IsClosed() && a.conn != nil && !a.conn.IsClosed() {
+		return a.ch, nil
+	}
+	a.reset()
+
+	conn, err := amqp.Dial(a.URL)
+	if err != nil {
+		return nil, fmt.Errorf("amqp dial: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(a.Exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp exchange declare: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp confirm mode: %w", err)
+	}
+
+	a.conn, a.ch = conn, ch
+	a.returns = ch.NotifyReturn(make(chan amqp.Return, amqpChunkSize))
+	return ch, nil
+}
+
+func (a *AMQP) reset() {
+	if a.conn != nil {
+		a.conn.Close()
+	}
+	a.conn, a.ch, a.returns = nil, nil, nil
+}
@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// режимы передачи CloudEvents по HTTP
+const (
+	CloudEventsStructured = "structured" // одно событие в теле application/cloudevents+json
+	CloudEventsBinary     = "binary"     // атрибуты в заголовках ce-*, в теле только data
+	CloudEventsBatch      = "batch"      // вся пачка одним application/cloudevents-batch+json
+)
+
+const cloudEventsDefaultSource = "/go_web_parser_agent"
+
+// CloudEvents отправляет каждый новый домен и ссылку как CloudEvent 1.0 по HTTP.
+// type — domain.discovered / sociallink.discovered, id строится из ID строки (domain:115),
+// time — created_at строки, data — те же поля, что в Webhook
+type CloudEvents struct {
+	URL    string
+	Source string
+	Mode   string
+	client *http.Client
+}
+
+func NewCloudEvents(url string) *CloudEvents {
+	return &CloudEvents{
+		URL:    url,
+		Source: cloudEventsDefaultSource,
+		Mode:   CloudEventsStructured,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *CloudEvents) Name() string {
+	return "cloudevents"
+}
+
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            any       `json:"data"`
+}
+
+func (c *CloudEvents) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	ces := make([]cloudEvent, 0, len(events))
+	for _, d := range events {
+		ces = append(ces, c.event(EventDomainDiscovered, d.EventID(), d.LandingDomain, d.CreatedAt, d.data()))
+	}
+	return c.send(ctx, ces)
+}
+
+func (c *CloudEvents) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	ces := make([]cloudEvent, 0, len(events))
+	for _, l := range events {
+		ces = append(ces, c.event(EventSocialLinkDiscovered, l.EventID(), l.URL, l.CreatedAt, l.data()))
+	}
+	return c.send(ctx, ces)
+}
+
+func (c *CloudEvents) event(typ, id, subject string, t time.Time, data any) cloudEvent {
+	return cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              id,
+		Source:          c.Source,
+		Type:            typ,
+		Subject:         subject,
+		Time:            t.UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+func (c *CloudEvents) send(ctx context.Context, events []cloudEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if c.Mode == CloudEventsBatch {
+		return c.post(ctx, events, http.Header{"Content-Type": {"application/cloudevents-batch+json"}})
+	}
+
+	for _, e := range events {
+		var err error
+		switch c.Mode {
+		case CloudEventsBinary:
+			err = c.post(ctx, e.Data, http.Header{
+				"Content-Type":   {e.DataContentType},
+				"Ce-Specversion": {e.SpecVersion},
+				"Ce-Id":          {ceHeaderValue(e.ID)},
+				"Ce-Source":      {ceHeaderValue(e.Source)},
+				"Ce-Type":        {ceHeaderValue(e.Type)},
+				"Ce-Subject":     {ceHeaderValue(e.Subject)},
+				"Ce-Time":        {e.Time.Format(time.RFC3339Nano)},
+			})
+		default:
+			err = c.post(ctx, e, http.Header{"Content-Type": {"application/cloudevents+json"}})
+		}
+		if err != nil {
+			return fmt.Errorf("cloudevent %s: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+// ceHeaderValue кодирует строковый атрибут для заголовка ce-* по HTTP binding (3.1.3.2): байты UTF-8
+// вне печатного ASCII, пробел, '"' и '%' передаются как %XX. Иначе IDN и кириллица в subject
+// ломают заголовок или по-разному декодируются на стороне получателя
+func ceHeaderValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || c == '"' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func (c *CloudEvents) post(ctx context.Context, body any, header http.Header) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	_, err = doRequest(c.client, "cloudevents", req)
+	return err
+}
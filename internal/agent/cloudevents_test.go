@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCloudEventsBinaryPercentEncodesHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	ce := NewCloudEvents(srv.URL)
+	ce.Mode = CloudEventsBinary
+	link := LinkEvent{
+		ID:        7,
+		URL:       `https://vk.com/группа "моя" 100%`,
+		PageURL:   "https://пример.рф/",
+		CreatedAt: time.Now(),
+	}
+	if err := ce.NotifyLinks(context.Background(), []LinkEvent{link}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := got.Get("Ce-Subject")
+	for i := 0; i < len(raw); i++ {
+		if c := raw[i]; c <= ' ' || c >= 0x7f || c == '"' {
+			t.Fatalf("Ce-Subject has unencoded byte %q: %s", c, raw)
+		}
+	}
+	subject, err := url.PathUnescape(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != link.URL {
+		t.Errorf("decoded Ce-Subject = %q, want %q", subject, link.URL)
+	}
+	if id := got.Get("Ce-Id"); id != link.EventID() {
+		t.Errorf("Ce-Id = %q, want %q", id, link.EventID())
+	}
+}
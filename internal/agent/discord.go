@@ -0,0 +1,198 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ограничения Discord на одно сообщение вебхука
+const (
+	discordMaxEmbeds     = 10
+	discordMaxEmbedChars = 6000
+	discordMaxTitle      = 256
+	discordMaxFieldValue = 1024
+	discordMaxRetries    = 3
+)
+
+// Discord шлёт уведомления во вебхук discord: по embed на элемент, не больше 10 embed'ов
+// и 6000 символов на сообщение, с учётом заголовков X-RateLimit-*; заголовки — из подписей Templates
+type Discord struct {
+	Webhook   string
+	Templates *Templates
+	client    *http.Client
+
+	// момент, до которого нельзя слать следующий запрос (исчерпан бакет);
+	// циклы доменов и ссылок шлют через один Discord параллельно, поэтому под mu
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+func NewDiscord(webhook string) *Discord {
+	return &Discord{
+		Webhook:   webhook,
+		Templates: DefaultTemplates(),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (d *Discord) Name() string {
+	return "discord"
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordEmbed struct {
+	Title     string         `json:"title,omitempty"`
+	URL       string         `json:"url,omitempty"`
+	Timestamp string         `json:"timestamp,omitempty"`
+	Fields    []discordField `json:"fields,omitempty"`
+}
+
+// size считает символы, которые Discord учитывает в лимите 6000
+func (e discordEmbed) size() int {
+	n := len([]rune(e.Title))
+	for _, f := range e.Fields {
+		n += len([]rune(f.Name)) + len([]rune(f.Value))
+	}
+	return n
+}
+
+type discordMessage struct {
+	Username string         `json:"username,omitempty"`
+	Content  string         `json:"content,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+func (d *Discord) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	embeds := make([]discordEmbed, 0, len(events))
+	for _, dom := range events {
+		embeds = append(embeds, discordEmbed{
+			Title:     truncateText(dom.LandingDomain, discordMaxTitle),
+			Timestamp: dom.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return d.send(ctx, "DomainWatcher", "**"+d.Templates.Label(d.Name(), "domains.title", len(events))+"**", embeds)
+}
+
+func (d *Discord) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	platform, page := d.Templates.Label(d.Name(), "platform", 0), d.Templates.Label(d.Name(), "page", 0)
+	embeds := make([]discordEmbed, 0, len(events))
+	for _, l := range events {
+		e := discordEmbed{
+			Title:     truncateText(l.URL, discordMaxTitle),
+			URL:       l.URL,
+			Timestamp: l.CreatedAt.Format(time.RFC3339),
+		}
+		if p := l.PlatformName(); p != "" {
+			e.Fields = append(e.Fields, discordField{Name: platform, Value: p, Inline: true})
+		}
+		if l.PageURL != "" {
+			e.Fields = append(e.Fields, discordField{Name: page, Value: truncateText(l.PageURL, discordMaxFieldValue)})
+		}
+		embeds = append(embeds, e)
+	}
+	return d.send(ctx, "LinkWatcher", "**"+d.Templates.Label(d.Name(), "links.title", len(events))+"**", embeds)
+}
+
+// send режет embed'ы на сообщения в пределах лимитов и отправляет их по очереди
+func (d *Discord) send(ctx context.Context, username, title string, embeds []discordEmbed) error {
+	var messages []discordMessage
+	cur := discordMessage{Username: username, Content: title}
+	size := 0
+	for _, e := range embeds {
+		if len(cur.Embeds) == discordMaxEmbeds || (len(cur.Embeds) > 0 && size+e.size() > discordMaxEmbedChars) {
+			messages = append(messages, cur)
+			cur = discordMessage{Username: username}
+			size = 0
+		}
+		cur.Embeds = append(cur.Embeds, e)
+		size += e.size()
+	}
+	if len(cur.Embeds) > 0 {
+		messages = append(messages, cur)
+	}
+
+	for i, msg := range messages {
+		if err := d.post(ctx, msg); err != nil {
+			return fmt.Errorf("discord message %d/%d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+func (d *Discord) untilUnblocked() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return time.Until(d.blockedUntil)
+}
+
+// block откладывает следующие запросы на wait, не сокращая уже выставленную паузу
+func (d *Discord) block(wait time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if until := time.Now().Add(wait); until.After(d.blockedUntil) {
+		d.blockedUntil = until
+	}
+}
+
+func (d *Discord) post(ctx context.Context, msg discordMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if wait := d.untilUnblocked(); wait > 0 {
+			if err := sleepCtx(ctx, wait); err != nil {
+				return err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Webhook, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			return err
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		resp.Body.Close()
+
+		// бакет исчерпан: следующий запрос только после X-RateLimit-Reset-After
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if secs, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Reset-After"), 64); err == nil {
+				d.block(time.Duration(secs * float64(time.Second)))
+			}
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < discordMaxRetries {
+			var rl struct {
+				RetryAfter float64 `json:"retry_after"`
+			}
+			wait := time.Second
+			if json.Unmarshal(body, &rl) == nil && rl.RetryAfter > 0 {
+				wait = time.Duration(rl.RetryAfter * float64(time.Second))
+			}
+			d.block(wait)
+			continue
+		}
+		if resp.StatusCode/100 != 2 {
+			return &StatusError{Sink: "discord webhook", Status: resp.Status, StatusCode: resp.StatusCode, Body: string(bytes.TrimSpace(body))}
+		}
+		return nil
+	}
+}
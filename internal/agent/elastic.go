@@ -0,0 +1,274 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Elastic индексирует домены и ссылки в Elasticsearch или OpenSearch через _bulk API.
+// Документы пишутся update + doc_as_upsert с _id = ID строки в таблице парсера,
+// поэтому повторная отправка и переиндексация не плодят дубликаты.
+// Клиент — голый HTTP: _bulk и _index_template одинаковы в обоих движках,
+// а официальный клиент Elasticsearch отказывается работать с OpenSearch
+type Elastic struct {
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	IndexPrefix string // индексы <prefix>-domains и <prefix>-social_links
+	BulkSize    int
+
+	client *http.Client
+
+	mu    sync.Mutex
+	ready bool // шаблоны индексов уже установлены
+}
+
+func NewElastic(url, indexPrefix string) *Elastic {
+	return &Elastic{
+		URL:         strings.TrimRight(url, "/"),
+		IndexPrefix: indexPrefix,
+		BulkSize:    500,
+		client:      &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (e *Elastic) Name() string {
+	return "elasticsearch"
+}
+
+func (e *Elastic) DomainIndex() string {
+	return e.IndexPrefix + "-domains"
+}
+
+func (e *Elastic) LinkIndex() string {
+	return e.IndexPrefix + "-social_links"
+}
+
+// документы индексов: поля таблиц парсера и производные от них для агрегаций
+type domainDoc struct {
+	ID            int       `json:"id"`
+	LandingDomain string    `json:"landing_domain"`
+	TLD           string    `json:"tld"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type linkDoc struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Host      string    `json:"host"`
+	Platform  string    `json:"platform"`
+	PageURL   string    `json:"page_url"`
+	PageHost  string    `json:"page_host"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// keyword для точных фильтров и агрегаций, подполе .text для полнотекстового поиска
+var keywordText = map[string]any{
+	"type":   "keyword",
+	"fields": map[string]any{"text": map[string]any{"type": "text"}},
+}
+
+func (e *Elastic) templates() map[string]any {
+	return map[string]any{
+		e.DomainIndex(): map[string]any{
+			"index_patterns": []string{e.DomainIndex() + "*"},
+			"template": map[string]any{
+				"mappings": map[string]any{
+					"dynamic": "strict",
+					"properties": map[string]any{
+						"id":             map[string]any{"type": "long"},
+						"landing_domain": keywordText,
+						"tld":            map[string]any{"type": "keyword"},
+						"created_at":     map[string]any{"type": "date"},
+					},
+				},
+			},
+		},
+		e.LinkIndex(): map[string]any{
+			"index_patterns": []string{e.LinkIndex() + "*"},
+			"template": map[string]any{
+				"mappings": map[string]any{
+					"dynamic": "strict",
+					"properties": map[string]any{
+						"id":         map[string]any{"type": "long"},
+						"url":        keywordText,
+						"host":       map[string]any{"type": "keyword"},
+						"platform":   map[string]any{"type": "keyword"},
+						"page_url":   keywordText,
+						"page_host":  map[string]any{"type": "keyword"},
+						"created_at": map[string]any{"type": "date"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// EnsureTemplates ставит (или обновляет) шаблоны индексов; уже созданные индексы маппинг не меняют
+func (e *Elastic) EnsureTemplates(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for name, tmpl := range e.templates() {
+		data, err := json.Marshal(tmpl)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, e.URL+"/_index_template/"+name, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		e.authorize(req)
+		if _, err := doRequest(e.client, e.Name(), req); err != nil {
+			return fmt.Errorf("put index template %s: %w", name, err)
+		}
+	}
+	e.ready = true
+	return nil
+}
+
+func (e *Elastic) ensureReady(ctx context.Context) error {
+	e.mu.Lock()
+	ready := e.ready
+	e.mu.Unlock()
+	if ready {
+		return nil
+	}
+	return e.EnsureTemplates(ctx)
+}
+
+func (e *Elastic) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	docs := make([]bulkDoc, 0, len(events))
+	for _, d := range events {
+		docs = append(docs, bulkDoc{id: d.ID, doc: domainDoc{
+			ID:            d.ID,
+			LandingDomain: d.LandingDomain,
+			TLD:           d.TLD(),
+			CreatedAt:     d.CreatedAt,
+		}})
+	}
+	return e.Bulk(ctx, e.DomainIndex(), docs)
+}
+
+func (e *Elastic) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	docs := make([]bulkDoc, 0, len(events))
+	for _, l := range events {
+		docs = append(docs, bulkDoc{id: l.ID, doc: linkDoc{
+			ID:        l.ID,
+			URL:       l.URL,
+			Host:      l.Host(),
+			Platform:  l.PlatformName(),
+			PageURL:   l.PageURL,
+			PageHost:  l.PageHost(),
+			CreatedAt: l.CreatedAt,
+		}})
+	}
+	return e.Bulk(ctx, e.LinkIndex(), docs)
+}
+
+type bulkDoc struct {
+	id  int
+	doc any
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// Bulk делает upsert документов в index пачками по BulkSize
+func (e *Elastic) Bulk(ctx context.Context, index string, docs []bulkDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := e.ensureReady(ctx); err != nil {
+		return err
+	}
+
+	size := e.BulkSize
+	if size <= 0 {
+		size = len(docs)
+	}
+	for start := 0; start < len(docs); start += size {
+		end := min(start+size, len(docs))
+		if err := e.bulk(ctx, index, docs[start:end]); err != nil {
+			return fmt.Errorf("bulk %s %d-%d: %w", index, start, end, err)
+		}
+	}
+	return nil
+}
+
+func (e *Elastic) bulk(ctx context.Context, index string, docs []bulkDoc) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, d := range docs {
+		action := map[string]any{"update": map[string]any{"_index": index, "_id": strconv.Itoa(d.id)}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(map[string]any{"doc": d.doc, "doc_as_upsert": true}); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL+"/_bulk", &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	e.authorize(req)
+
+	data, err := doRequest(e.client, e.Name(), req)
+	if err != nil {
+		return err
+	}
+	var resp bulkResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("decode bulk response: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	// _bulk отвечает 200 даже при ошибках отдельных документов, собираем первые из них
+	var errs []error
+	failed := 0
+	for _, item := range resp.Items {
+		for _, r := range item {
+			if r.Error == nil {
+				continue
+			}
+			failed++
+			if len(errs) < 5 {
+				errs = append(errs, fmt.Errorf("_id %s: %s: %s", r.ID, r.Error.Type, r.Error.Reason))
+			}
+		}
+	}
+	return fmt.Errorf("%d of %d documents failed: %w", failed, len(docs), errors.Join(errs...))
+}
+
+func (e *Elastic) authorize(req *http.Request) {
+	switch {
+	case e.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+e.APIKey)
+	case e.Username != "":
+		req.SetBasicAuth(e.Username, e.Password)
+	}
+}
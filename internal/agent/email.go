@@ -0,0 +1,334 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// режимы шифрования smtp
+const (
+	SMTPStartTLS = "starttls"
+	SMTPTLS      = "tls" // неявный TLS, обычно порт 465
+	SMTPPlain    = "none"
+)
+
+// Email рассылает дайджесты новых доменов и ссылок письмами multipart/alternative (text + html).
+// При Window > 0 события копятся и уходят одним письмом раз в окно (нужен запущенный Run);
+// такие события считаются доставленными сразу после попадания в буфер, поэтому при падении
+// процесса неотправленный дайджест теряется, и окно несовместимо с DELIVERY=outbox.
+// Буфер ограничен MaxBuffered событиями: когда он полон, пачка отклоняется с ErrEmailBufferFull,
+// и цикл сканирования повторит её после следующей отправки дайджеста.
+// Текстовая часть письма — шаблон email/digest.text, тема и подписи HTML-части — из подписей Templates
+type Email struct {
+	Host        string
+	Port        string
+	Username    string
+	Password    string
+	TLSMode     string
+	From        string
+	To          []string
+	Window      time.Duration
+	MaxBuffered int
+	Templates   *Templates
+
+	mu      sync.Mutex
+	domains []DomainEvent
+	links   []LinkEvent
+}
+
+func NewEmail(host, port, from string, to []string) *Email {
+	return &Email{
+		Host:        host,
+		Port:        port,
+		TLSMode:     SMTPStartTLS,
+		From:        from,
+		To:          to,
+		MaxBuffered: 10000,
+		Templates:   DefaultTemplates(),
+	}
+}
+
+func (e *Email) Name() string {
+	return "email"
+}
+
+var ErrEmailBufferFull = errors.New("email digest buffer is full")
+
+// bufferFull проверяет, влезут ли ещё n событий; вызывается под mu
+func (e *Email) bufferFull(n int) bool {
+	return e.MaxBuffered > 0 && len(e.domains)+len(e.links)+n > e.MaxBuffered
+}
+
+func (e *Email) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	if e.Window > 0 {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.bufferFull(len(events)) {
+			return ErrEmailBufferFull
+		}
+		e.domains = append(e.domains, events...)
+		return nil
+	}
+	return e.sendDigest(ctx, events, nil)
+}
+
+func (e *Email) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	if e.Window > 0 {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.bufferFull(len(events)) {
+			return ErrEmailBufferFull
+		}
+		e.links = append(e.links, events...)
+		return nil
+	}
+	return e.sendDigest(ctx, nil, events)
+}
+
+// Run отправляет накопленный дайджест раз в Window; при ошибке события остаются в буфере до следующего окна
+func (e *Email) Run(ctx context.Context) {
+	if e.Window <= 0 {
+		return
+	}
+	t := time.NewTicker(e.Window)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// последняя попытка отправить то, что успели накопить
+			flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			e.flush(flushCtx)
+			cancel()
+			return
+		case <-t.C:
+			e.flush(ctx)
+		}
+	}
+}
+
+func (e *Email) flush(ctx context.Context) {
+	e.mu.Lock()
+	domains, links := e.domains, e.links
+	e.domains, e.links = nil, nil
+	e.mu.Unlock()
+
+	if len(domains) == 0 && len(links) == 0 {
+		return
+	}
+	if err := e.sendDigest(ctx, domains, links); err != nil {
+		log.Error().Err(err).Int("domains", len(domains)).Int("links", len(links)).Msg("email digest failed")
+		e.mu.Lock()
+		e.domains = append(domains, e.domains...)
+		e.links = append(links, e.links...)
+		e.mu.Unlock()
+	}
+}
+
+// emailDigest — события письма и подписи в локали Templates
+type emailDigest struct {
+	Domains []DomainEvent
+	Links   []LinkEvent
+
+	Subject      string
+	DomainsTitle string
+	LinksTitle   string
+	Link         string
+	Platform     string
+	Page         string
+}
+
+func (e *Email) digest(domains []DomainEvent, links []LinkEvent) emailDigest {
+	label := func(key string, n int) string { return e.Templates.Label(e.Name(), key, n) }
+	d := emailDigest{
+		Domains:  domains,
+		Links:    links,
+		Link:     label("link", 0),
+		Platform: label("platform", 0),
+		Page:     label("page", 0),
+	}
+	var parts []string
+	if len(domains) > 0 {
+		d.DomainsTitle = label("domains.title", len(domains))
+		parts = append(parts, label("subject.domains", len(domains)))
+	}
+	if len(links) > 0 {
+		d.LinksTitle = label("links.title", len(links))
+		parts = append(parts, label("subject.links", len(links)))
+	}
+	d.Subject = label("subject.prefix", 0) + ": " + strings.Join(parts, ", ")
+	return d
+}
+
+var emailHTML = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html><body style="font-family: sans-serif">
+{{- if .Domains}}
+<h3>{{.DomainsTitle}}</h3>
+<ul>
+{{- range .Domains}}
+<li>{{.LandingDomain}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Links}}
+<h3>{{.LinksTitle}}</h3>
+<table cellpadding="4" style="border-collapse: collapse">
+<tr><th align="left">{{.Link}}</th><th align="left">{{.Platform}}</th><th align="left">{{.Page}}</th></tr>
+{{- range .Links}}
+<tr><td><a href="{{.URL}}">{{.URL}}</a></td><td>{{.PlatformName}}</td><td><a href="{{.PageURL}}">{{.PageURL}}</a></td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body></html>
+`))
+
+func (e *Email) sendDigest(ctx context.Context, domains []DomainEvent, links []LinkEvent) error {
+	if len(domains) == 0 && len(links) == 0 {
+		return nil
+	}
+	msg, err := e.buildMessage(e.digest(domains, links))
+	if err != nil {
+		return err
+	}
+	return e.deliver(ctx, msg)
+}
+
+func (e *Email) buildMessage(d emailDigest) ([]byte, error) {
+	var html bytes.Buffer
+	if err := emailHTML.Execute(&html, d); err != nil {
+		return nil, err
+	}
+	text, err := e.Templates.Render(e.Name(), TemplateDigest, "text", TemplateData{
+		Domains: d.Domains,
+		Links:   d.Links,
+		Count:   len(d.Domains) + len(d.Links),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", []byte(text + "\n")},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(w)
+		if _, err := qw.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", d.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", mw.Boundary())
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// deliver открывает smtp-сессию в выбранном режиме TLS и отправляет письмо всем получателям
+func (e *Email) deliver(ctx context.Context, msg []byte) error {
+	addr := net.JoinHostPort(e.Host, e.Port)
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	tlsConfig := &tls.Config{ServerName: e.Host}
+
+	var conn net.Conn
+	var err error
+	if e.TLSMode == SMTPTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(time.Minute))
+	}
+
+	c, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if e.TLSMode == SMTPStartTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if e.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.Username, e.Password, e.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	from, err := mail.ParseAddress(e.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	// в заголовке To допустимо "Имя <a@b>", а RCPT TO принимает только сам адрес
+	for _, to := range e.To {
+		rcpt, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid to address %q: %w", to, err)
+		}
+		if err := c.Rcpt(rcpt.Address); err != nil {
+			return fmt.Errorf("rcpt %s: %w", rcpt.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,48 @@
+package agent
+
+import "strings"
+
+// envelope — тело сообщения для брокеров (nats, kafka, redis, amqp): одно событие на сообщение,
+// поля те же, что у события в Webhook, плюс тип и версия схемы
+type envelope struct {
+	SchemaVersion int           `json:"schema_version"`
+	Type          string        `json:"type"`
+	ID            string        `json:"id"`
+	Cursor        webhookCursor `json:"cursor"`
+	Data          any           `json:"data"`
+}
+
+func domainEnvelope(d DomainEvent) envelope {
+	return envelope{
+		SchemaVersion: WebhookSchemaVersion,
+		Type:          EventDomainDiscovered,
+		ID:            d.EventID(),
+		Cursor:        webhookCursor{CreatedAt: d.CreatedAt, ID: d.ID},
+		Data:          d.data(),
+	}
+}
+
+func linkEnvelope(l LinkEvent) envelope {
+	return envelope{
+		SchemaVersion: WebhookSchemaVersion,
+		Type:          EventSocialLinkDiscovered,
+		ID:            l.EventID(),
+		Cursor:        webhookCursor{CreatedAt: l.CreatedAt, ID: l.ID},
+		Data:          l.data(),
+	}
+}
+
+// routingToken превращает платформу в один токен subject'а / routing key:
+// точки и пробелы заменяются на "_", пустая платформа — "unknown"
+func routingToken(platform string) string {
+	if platform == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ' ', '*', '>', '#', '\t':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(platform))
+}
@@ -0,0 +1,270 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	"github.com/rs/zerolog/log"
+)
+
+// действия фильтра
+const (
+	FilterDrop     = "drop"     // событие не уходит ни в один sink
+	FilterTag      = "tag"      // к событию добавляется Tag
+	FilterSeverity = "severity" // событию проставляется Severity
+)
+
+// FilterConfig — фильтры из FILTERS_FILE (JSON):
+//
+//	{
+//	  "filters": [
+//	    {"name": "own-vk", "expr": "platform == 'vk.com' && url.contains('/ourcompany')", "action": "drop"},
+//	    {"name": "ru", "expr": "type == 'domain' && tld in ['ru', 'su']", "action": "severity", "severity": "high"},
+//	    {"name": "casino", "expr": "url.matches('(?i)casino|slot')", "action": "tag", "tag": "gambling"}
+//	  ]
+//	}
+//
+// Выражение CEL должно возвращать bool. Переменные (для чужого типа сущности — пустые строки):
+//
+//	type            "domain" или "sociallink"
+//	id              int, ID строки в таблице парсера
+//	created_at      timestamp
+//	landing_domain  Domain.landing_domain
+//	tld             зона landing_domain без точки
+//	url, page_url   поля SocialLink
+//	domain          SocialLink.domain как есть
+//	platform        SocialLink.domain, а если он пустой — хост url
+//	host, page_host хосты url и page_url без "www."
+//
+// Фильтры применяются по порядку; drop прекращает обработку, более поздний severity перекрывает ранний
+type FilterConfig struct {
+	Filters []FilterRule `json:"filters"`
+}
+
+type FilterRule struct {
+	Name     string `json:"name"`
+	Expr     string `json:"expr"`
+	Action   string `json:"action"`
+	Tag      string `json:"tag"`
+	Severity string `json:"severity"`
+}
+
+// Filter — скомпилированное правило
+type Filter struct {
+	FilterRule
+	prg cel.Program
+}
+
+// Filters — набор скомпилированных правил, применяется к событиям перед отправкой
+type Filters struct {
+	Rules []*Filter
+}
+
+func LoadFilterConfig(path string) (*FilterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg FilterConfig
+	if err := decodeStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+func filterEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("type", cel.StringType),
+		cel.Variable("id", cel.IntType),
+		cel.Variable("created_at", cel.TimestampType),
+		cel.Variable("landing_domain", cel.StringType),
+		cel.Variable("tld", cel.StringType),
+		cel.Variable("url", cel.StringType),
+		cel.Variable("page_url", cel.StringType),
+		cel.Variable("domain", cel.StringType),
+		cel.Variable("platform", cel.StringType),
+		cel.Variable("host", cel.StringType),
+		cel.Variable("page_host", cel.StringType),
+	)
+}
+
+// CompileFilters проверяет и компилирует правила; ошибка указывает на правило и позицию в выражении
+func CompileFilters(cfg *FilterConfig) (*Filters, error) {
+	env, err := filterEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	fs := &Filters{}
+	for i, r := range cfg.Filters {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", i+1)
+		}
+		switch r.Action {
+		case FilterDrop:
+		case FilterTag:
+			if r.Tag == "" {
+				return nil, fmt.Errorf("filter %q: tag is required for action tag", r.Name)
+			}
+		case FilterSeverity:
+			if r.Severity == "" {
+				return nil, fmt.Errorf("filter %q: severity is required for action severity", r.Name)
+			}
+		default:
+			return nil, fmt.Errorf("filter %q: unknown action %q", r.Name, r.Action)
+		}
+
+		ast, iss := env.Compile(r.Expr)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("filter %q: %w", r.Name, iss.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("filter %q: expression must return bool, got %s", r.Name, ast.OutputType())
+		}
+		prg, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", r.Name, err)
+		}
+		fs.Rules = append(fs.Rules, &Filter{FilterRule: r, prg: prg})
+	}
+	return fs, nil
+}
+
+func domainVars(e DomainEvent) map[string]any {
+	return map[string]any{
+		"type":           RouteTypeDomain,
+		"id":             e.ID,
+		"created_at":     e.CreatedAt,
+		"landing_domain": e.LandingDomain,
+		"tld":            e.TLD(),
+		"url":            "",
+		"page_url":       "",
+		"domain":         "",
+		"platform":       "",
+		"host":           "",
+		"page_host":      "",
+	}
+}
+
+func linkVars(e LinkEvent) map[string]any {
+	return map[string]any{
+		"type":           RouteTypeSocialLink,
+		"id":             e.ID,
+		"created_at":     e.CreatedAt,
+		"landing_domain": "",
+		"tld":            "",
+		"url":            e.URL,
+		"page_url":       e.PageURL,
+		"domain":         e.Platform,
+		"platform":       e.PlatformName(),
+		"host":           e.Host(),
+		"page_host":      e.PageHost(),
+	}
+}
+
+func (f *Filter) eval(vars map[string]any) (bool, error) {
+	out, _, err := f.prg.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("filter %q returned %T", f.Name, out.Value())
+	}
+	return matched, nil
+}
+
+func (f *Filter) MatchDomain(e DomainEvent) (bool, error) {
+	return f.eval(domainVars(e))
+}
+
+func (f *Filter) MatchLink(e LinkEvent) (bool, error) {
+	return f.eval(linkVars(e))
+}
+
+// apply прогоняет переменные события через правила; ошибка вычисления считается несовпадением
+func (fs *Filters) apply(id string, vars map[string]any, tags *[]string, severity *string) bool {
+	for _, f := range fs.Rules {
+		matched, err := f.eval(vars)
+		if err != nil {
+			log.Warn().Err(err).Str("filter", f.Name).Str("event", id).Msg("filter evaluation failed")
+			continue
+		}
+		if !matched {
+			continue
+		}
+		switch f.Action {
+		case FilterDrop:
+			return false
+		case FilterTag:
+			if !slices.Contains(*tags, f.Tag) {
+				*tags = append(*tags, f.Tag)
+			}
+		case FilterSeverity:
+			*severity = f.Severity
+		}
+	}
+	return true
+}
+
+// Domains возвращает события, которые не отброшены, с проставленными тегами и severity
+func (fs *Filters) Domains(events []DomainEvent) []DomainEvent {
+	out := make([]DomainEvent, 0, len(events))
+	for _, e := range events {
+		e.Tags = slices.Clone(e.Tags)
+		if fs.apply(e.EventID(), domainVars(e), &e.Tags, &e.Severity) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (fs *Filters) Links(events []LinkEvent) []LinkEvent {
+	out := make([]LinkEvent, 0, len(events))
+	for _, e := range events {
+		e.Tags = slices.Clone(e.Tags)
+		if fs.apply(e.EventID(), linkVars(e), &e.Tags, &e.Severity) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// filteredSink применяет фильтры перед sink'ом; пачка, отброшенная целиком, в sink не уходит
+type filteredSink struct {
+	base    Notifier
+	filters *Filters
+}
+
+// FilterNotifiers оборачивает каждый sink фильтрами. Имена sink'ов сохраняются,
+// поэтому в режиме outbox фильтры применяются при доставке
+func FilterNotifiers(fs *Filters, sinks []Notifier) []Notifier {
+	out := make([]Notifier, 0, len(sinks))
+	for _, n := range sinks {
+		out = append(out, &filteredSink{base: n, filters: fs})
+	}
+	return out
+}
+
+func (s *filteredSink) Name() string {
+	return s.base.Name()
+}
+
+func (s *filteredSink) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	events = s.filters.Domains(events)
+	if len(events) == 0 {
+		return nil
+	}
+	return s.base.NotifyDomains(ctx, events)
+}
+
+func (s *filteredSink) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	events = s.filters.Links(events)
+	if len(events) == 0 {
+		return nil
+	}
+	return s.base.NotifyLinks(ctx, events)
+}
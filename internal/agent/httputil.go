@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError — не-2xx ответ sink'а
+type StatusError struct {
+	Sink       string
+	Status     string
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // из заголовка Retry-After или тела ответа, 0 если не задан
+}
+
+func (e *StatusError) Error() string {
+	if e.Body != "" {
+		return fmt.Sprintf("%s returned %s: %s", e.Sink, e.Status, e.Body)
+	}
+	return fmt.Sprintf("%s returned %s", e.Sink, e.Status)
+}
+
+// postJSON отправляет payload как JSON и возвращает тело успешного ответа
+func postJSON(ctx context.Context, client *http.Client, sink, url string, payload any, header http.Header) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doRequest(client, sink, req)
+}
+
+// doRequest выполняет запрос и превращает не-2xx ответ в *StatusError
+func doRequest(client *http.Client, sink string, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		se := &StatusError{
+			Sink:       sink,
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Body:       string(bytes.TrimSpace(body)),
+		}
+		if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil {
+			se.RetryAfter = time.Duration(secs * float64(time.Second))
+		}
+		return nil, se
+	}
+	return body, nil
+}
+
+// sleepCtx ждёт d или отмены ctx
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
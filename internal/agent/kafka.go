@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+// Kafka публикует домены и ссылки синхронным продюсером: ключ сообщения — landing_domain
+// или платформа ссылки, поэтому события одного домена/платформы попадают в одну партицию по порядку.
+// NotifyDomains/NotifyLinks возвращаются только после подтверждения брокера, так что курсор
+// сдвигается не раньше, чем сообщения записаны с выбранным уровнем acks
+type Kafka struct {
+	DomainTopic string
+	LinkTopic   string
+	producer    sarama.SyncProducer
+}
+
+// KafkaConfig — настройки продюсера
+type KafkaConfig struct {
+	Brokers     []string
+	DomainTopic string
+	LinkTopic   string
+	Acks        string // all, one или none
+	Idempotent  bool   // требует Acks=all
+	ClientID    string
+}
+
+func NewKafka(cfg KafkaConfig) (*Kafka, error) {
+	sc := sarama.NewConfig()
+	sc.Version = sarama.V2_1_0_0
+	sc.ClientID = cfg.ClientID
+	if sc.ClientID == "" {
+		sc.ClientID = "go_web_parser_agent"
+	}
+	sc.Producer.Return.Successes = true
+	sc.Producer.Partitioner = sarama.NewHashPartitioner
+	sc.Producer.Retry.Max = 10
+
+	switch cfg.Acks {
+	case "", "all":
+		sc.Producer.RequiredAcks = sarama.WaitForAll
+	case "one":
+		sc.Producer.RequiredAcks = sarama.WaitForLocal
+	case "none":
+		sc.Producer.RequiredAcks = sarama.NoResponse
+	default:
+		return nil, fmt.Errorf("unknown kafka acks %q", cfg.Acks)
+	}
+
+	if cfg.Idempotent {
+		if sc.Producer.RequiredAcks != sarama.WaitForAll {
+			return nil, fmt.Errorf("idempotent kafka producer requires acks=all")
+		}
+		sc.Producer.Idempotent = true
+		sc.Net.MaxOpenRequests = 1
+	}
+
+	producer, err := sarama.NewSyncProducer(cfg.Brokers, sc)
+	if err != nil {
+		return nil, fmt.Errorf("kafka producer: %w", err)
+	}
+	return &Kafka{
+		DomainTopic: cfg.DomainTopic,
+		LinkTopic:   cfg.LinkTopic,
+		producer:    producer,
+	}, nil
+}
+
+func (k *Kafka) Name() string {
+	return "kafka"
+}
+
+func (k *Kafka) Close() error {
+	return k.producer.Close()
+}
+
+func (k *Kafka) NotifyDomains(_ context.Context, events []DomainEvent) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, d := range events {
+		msg, err := kafkaMessage(k.DomainTopic, d.LandingDomain, domainEnvelope(d))
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return k.send(msgs)
+}
+
+func (k *Kafka) NotifyLinks(_ context.Context, events []LinkEvent) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, l := range events {
+		msg, err := kafkaMessage(k.LinkTopic, l.PlatformName(), linkEnvelope(l))
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return k.send(msgs)
+}
+
+func kafkaMessage(topic, key string, env envelope) (*sarama.ProducerMessage, error) {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(data),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("event-id"), Value: []byte(env.ID)},
+			{Key: []byte("event-type"), Value: []byte(env.Type)},
+		},
+	}, nil
+}
+
+func (k *Kafka) send(msgs []*sarama.ProducerMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := k.producer.SendMessages(msgs); err != nil {
+		if perrs, ok := err.(sarama.ProducerErrors); ok && len(perrs) > 0 {
+			return fmt.Errorf("kafka: %d of %d messages failed, first: %w", len(perrs), len(msgs), perrs[0].Err)
+		}
+		return err
+	}
+	return nil
+}
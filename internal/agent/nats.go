@@ -0,0 +1,116 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATS публикует каждый новый домен в <prefix>.domain.new, а ссылку — в <prefix>.link.new.<платформа>.
+// С JetStream пачка считается доставленной только после подтверждения всех публикаций,
+// поэтому курсор не сдвигается раньше, чем сообщения сохранены в стриме; Nats-Msg-Id = id события
+// даёт дедупликацию повторов на стороне сервера
+type NATS struct {
+	Prefix string
+	nc     *nats.Conn
+	js     jetstream.JetStream
+}
+
+func NewNATS(url, prefix string, useJetStream bool) (*NATS, error) {
+	nc, err := nats.Connect(url,
+		nats.Name("go_web_parser_agent"),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nats connect: %w", err)
+	}
+
+	n := &NATS{Prefix: prefix, nc: nc}
+	if useJetStream {
+		if n.js, err = jetstream.New(nc); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("jetstream: %w", err)
+		}
+	}
+	return n, nil
+}
+
+func (n *NATS) Name() string {
+	return "nats"
+}
+
+func (n *NATS) Close() error {
+	n.nc.Close()
+	return nil
+}
+
+func (n *NATS) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	msgs := make([]*nats.Msg, 0, len(events))
+	for _, d := range events {
+		msg, err := n.message(n.Prefix+".domain.new", domainEnvelope(d))
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return n.publish(ctx, msgs)
+}
+
+func (n *NATS) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	msgs := make([]*nats.Msg, 0, len(events))
+	for _, l := range events {
+		msg, err := n.message(n.Prefix+".link.new."+routingToken(l.PlatformName()), linkEnvelope(l))
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return n.publish(ctx, msgs)
+}
+
+func (n *NATS) message(subject string, env envelope) (*nats.Msg, error) {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header.Set(jetstream.MsgIDHeader, env.ID)
+	msg.Header.Set("Content-Type", "application/json")
+	return msg, nil
+}
+
+// publish без JetStream отдаёт сообщения и дожидается flush; с JetStream публикует асинхронно
+// и ждёт подтверждения по каждому сообщению
+func (n *NATS) publish(ctx context.Context, msgs []*nats.Msg) error {
+	if n.js == nil {
+		for _, msg := range msgs {
+			if err := n.nc.PublishMsg(msg); err != nil {
+				return err
+			}
+		}
+		return n.nc.FlushWithContext(ctx)
+	}
+
+	futures := make([]jetstream.PubAckFuture, 0, len(msgs))
+	for _, msg := range msgs {
+		f, err := n.js.PublishMsgAsync(msg)
+		if err != nil {
+			return fmt.Errorf("publish %s: %w", msg.Subject, err)
+		}
+		futures = append(futures, f)
+	}
+	for _, f := range futures {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.Ok():
+		case err := <-f.Err():
+			return fmt.Errorf("publish %s: %w", f.Msg().Subject, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// runNATSServer поднимает встроенный nats-server с JetStream в temp-каталоге
+func runNATSServer(t *testing.T) *server.Server {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server is not ready")
+	}
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func TestNATSJetStreamAcksAndDedupe(t *testing.T) {
+	srv := runNATSServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := js.CreateStream(ctx, jetstream.StreamConfig{Name: "AGENT", Subjects: []string{"agent.>"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := NewNATS(srv.ClientURL(), "agent", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+
+	now := time.Now()
+	domains := []DomainEvent{
+		{ID: 1, LandingDomain: "a.example.com", CreatedAt: now},
+		{ID: 2, LandingDomain: "b.example.com", CreatedAt: now},
+	}
+	links := []LinkEvent{{ID: 1, URL: "https://t.me/chan", PageURL: "https://a.example.com", CreatedAt: now}}
+
+	// повтор той же пачки, как после сбоя до сохранения курсора, не должен дублировать сообщения в стриме
+	for range 2 {
+		if err := n.NotifyDomains(ctx, domains); err != nil {
+			t.Fatal(err)
+		}
+		if err := n.NotifyLinks(ctx, links); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 3 {
+		t.Fatalf("stream has %d messages, want 3", info.State.Msgs)
+	}
+
+	msg, err := stream.GetLastMsgForSubject(ctx, "agent.link.new.t_me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := msg.Header.Get(jetstream.MsgIDHeader); id != links[0].EventID() {
+		t.Errorf("Nats-Msg-Id = %q, want %q", id, links[0].EventID())
+	}
+}
+
+func TestNATSJetStreamFailsWithoutStream(t *testing.T) {
+	srv := runNATSServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	n, err := NewNATS(srv.ClientURL(), "agent", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+
+	// без стрима на subject подтверждения не будет, и пачка не должна считаться доставленной
+	err = n.NotifyDomains(ctx, []DomainEvent{{ID: 1, LandingDomain: "a.example.com", CreatedAt: time.Now()}})
+	if err == nil {
+		t.Fatal("expected an error without a stream")
+	}
+}
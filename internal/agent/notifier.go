@@ -0,0 +1,212 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+)
+
+// DomainEvent — новый домен, найденный в таблице domains
+type DomainEvent struct {
+	ID            int
+	LandingDomain string
+	CreatedAt     time.Time
+
+	// проставляются фильтрами, см. Filters
+	Tags     []string
+	Severity string
+}
+
+// LinkEvent — новая ссылка на соцсеть из таблицы social_links
+type LinkEvent struct {
+	ID        int
+	URL       string
+	PageURL   string
+	Platform  string // SocialLink.domain: t.me, vk.com и т.д., может быть пустым
+	CreatedAt time.Time
+
+	Tags     []string
+	Severity string
+}
+
+// PlatformName возвращает платформу ссылки: поле domain, а если оно пустое — хост из URL
+func (e LinkEvent) PlatformName() string {
+	if e.Platform != "" {
+		return e.Platform
+	}
+	if u, err := url.Parse(e.URL); err == nil && u.Hostname() != "" {
+		return strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	return ""
+}
+
+// TLD возвращает последнюю метку landing_domain в нижнем регистре: "example.co.uk" → "uk"
+func (e DomainEvent) TLD() string {
+	host := strings.TrimSuffix(strings.ToLower(e.LandingDomain), ".")
+	if i := strings.LastIndexByte(host, '.'); i >= 0 {
+		return host[i+1:]
+	}
+	return ""
+}
+
+// Host — хост ссылки без "www."
+func (e LinkEvent) Host() string {
+	return hostOf(e.URL)
+}
+
+// PageHost — хост страницы, на которой нашли ссылку, без "www."
+func (e LinkEvent) PageHost() string {
+	return hostOf(e.PageURL)
+}
+
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+func DomainEvents(domains []*ent.Domain) []DomainEvent {
+	events := make([]DomainEvent, 0, len(domains))
+	for _, d := range domains {
+		events = append(events, DomainEvent{
+			ID:            d.ID,
+			LandingDomain: d.LandingDomain,
+			CreatedAt:     d.CreatedAt,
+		})
+	}
+	return events
+}
+
+func LinkEvents(links []*ent.SocialLink) []LinkEvent {
+	events := make([]LinkEvent, 0, len(links))
+	for _, l := range links {
+		events = append(events, LinkEvent{
+			ID:        l.ID,
+			URL:       l.URL,
+			PageURL:   l.PageURL,
+			Platform:  l.Domain,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	return events
+}
+
+// Notifier — sink, в который уходят пачки новых доменов и ссылок
+type Notifier interface {
+	// Name — имя sink'а, под ним он фигурирует в outbox и логах
+	Name() string
+	NotifyDomains(ctx context.Context, events []DomainEvent) error
+	NotifyLinks(ctx context.Context, events []LinkEvent) error
+}
+
+// Runner — sink с фоновой работой (например, отложенной отправкой дайджестов), его Run запускается рядом с циклами
+type Runner interface {
+	Run(ctx context.Context)
+}
+
+// Fanout рассылает каждую пачку во все sink'и и возвращает объединённую ошибку.
+// Если часть sink'ов упала, цикл сканирования повторит пачку, но Fanout помнит, какие события
+// каждый sink уже принял, и при повторе отдаёт ему только недоставленные — исправные sink'и
+// не получают дубликатов, пока сломанный восстанавливается. Память держится только до полного
+// успеха пачки; после рестарта процесса повтор всё же возможен, для доставки ровно один раз
+// на sink используйте DELIVERY=outbox
+type Fanout struct {
+	sinks     []Notifier
+	delivered *deliveryLog
+}
+
+func NewFanout(sinks []Notifier) *Fanout {
+	return &Fanout{sinks: sinks, delivered: newDeliveryLog(len(sinks))}
+}
+
+func (f *Fanout) Name() string {
+	return "fanout"
+}
+
+func (f *Fanout) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	return fanout(f, events, DomainEvent.EventID, func(n Notifier, batch []DomainEvent) error {
+		return n.NotifyDomains(ctx, batch)
+	})
+}
+
+func (f *Fanout) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	return fanout(f, events, LinkEvent.EventID, func(n Notifier, batch []LinkEvent) error {
+		return n.NotifyLinks(ctx, batch)
+	})
+}
+
+func fanout[E any](f *Fanout, events []E, id func(E) string, notify func(Notifier, []E) error) error {
+	var errs []error
+	for i, n := range f.sinks {
+		// пропускаем то, что sink уже принял при прошлой, частично неудачной попытке
+		pending := pendingFor(f.delivered, i, events, id)
+		if len(pending) == 0 {
+			continue
+		}
+		if err := notify(n, pending); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			continue
+		}
+		markDelivered(f.delivered, i, pending, id)
+	}
+
+	// пачка дошла до всех: курсор сдвинется, и помнить её события больше не нужно
+	if len(errs) == 0 {
+		forgetDelivered(f.delivered, events, id)
+	}
+	return errors.Join(errs...)
+}
+
+// deliveryLog помнит по каждому получателю EventID событий, которые он принял, пока пачка
+// целиком не дошла до всех; используется Fanout (получатель — sink) и routedSink (получатель — маршрут)
+type deliveryLog struct {
+	mu   sync.Mutex
+	seen []map[string]struct{}
+}
+
+func newDeliveryLog(n int) *deliveryLog {
+	l := &deliveryLog{seen: make([]map[string]struct{}, n)}
+	for i := range l.seen {
+		l.seen[i] = make(map[string]struct{})
+	}
+	return l
+}
+
+// pendingFor возвращает события, которые получатель i ещё не принимал
+func pendingFor[E any](l *deliveryLog, i int, events []E, id func(E) string) []E {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := make([]E, 0, len(events))
+	for _, e := range events {
+		if _, ok := l.seen[i][id(e)]; !ok {
+			pending = append(pending, e)
+		}
+	}
+	return pending
+}
+
+func markDelivered[E any](l *deliveryLog, i int, events []E, id func(E) string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range events {
+		l.seen[i][id(e)] = struct{}{}
+	}
+}
+
+func forgetDelivered[E any](l *deliveryLog, events []E, id func(E) string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, seen := range l.seen {
+		for _, e := range events {
+			delete(seen, id(e))
+		}
+	}
+}
@@ -2,80 +2,429 @@ package agent
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
+)
 
-	"github.com/zeshi09/go_web_parser_agent/ent"
+// встроенные форматы сообщений mattermost, выбираются маршрутом; своим форматом может быть
+// любой шаблон <kind>.<format>.tmpl из TEMPLATES_DIR
+const (
+	MMFormatList    = "list"    // по строке на событие
+	MMFormatCompact = "compact" // всё через запятую одним абзацем
+	MMFormatTable   = "table"   // markdown-таблица
 )
 
-func NotifyMMDomains(webhook string, domains []*ent.Domain) error {
-	var b strings.Builder
-	b.WriteString("**Появились новые домены:**\n")
-	for _, d := range domains {
-		b.WriteString("- ")
-		b.WriteString(d.LandingDomain)
-		b.WriteString("\n")
+// форматы вложения со списком событий
+const (
+	MMAttachCSV   = "csv"
+	MMAttachJSONL = "jsonl"
+)
+
+// mattermost по умолчанию отклоняет посты длиннее 16383 символов
+const mmDefaultMaxPostSize = 16383
+
+// Mattermost шлёт уведомления во входящий вебхук mattermost.
+// Channel переопределяет канал вебхука, если вебхуку это разрешено; текст собирается из Templates.
+// Текст длиннее MaxPostSize уходит несколькими постами с номерами частей [1/3], [2/3], ...;
+// если часть не ушла, при повторе пачки уже опубликованные части не дублируются
+// Если событий в пачке больше AttachThreshold, вместо списка публикуется сводка (шаблон <kind>.summary)
+// с файлом CSV или JSONL: файл загружается через /api/v4/files, пост создаётся через /api/v4/posts
+// от имени токена APIToken — входящий вебхук прикреплять файлы не умеет. Пост уходит в канал ChannelID,
+// а если задан Channel — в канал с этим именем из команды канала ChannelID (ID ищется через API и кэшируется)
+type Mattermost struct {
+	Webhook   string
+	Channel   string
+	Format    string
+	Templates *Templates
+
+	MaxPostSize     int
+	AttachThreshold int    // 0 — всегда слать списком
+	AttachFormat    string // csv или jsonl
+	APIURL          string // адрес сервера, например https://mm.example.com
+	APIToken        string
+	ChannelID       string
+
+	client   *http.Client
+	channels *mmChannelCache // общий для копий из Retarget
+	sent     *mmSentParts    // общий для копий из Retarget, ключ включает канал
+}
+
+// mmSentParts помнит части длинного текста, уже опубликованные при неудачной попытке
+type mmSentParts struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+// mmMaxSentParts — предел памяти о частях: пачки, которые так и не дошли целиком и больше не повторяются,
+// не должны копиться бесконечно
+const mmMaxSentParts = 10000
+
+// mmChannelCache — ID каналов по имени для постов через API
+type mmChannelCache struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func NewMattermost(webhook string) *Mattermost {
+	return &Mattermost{
+		Webhook:      webhook,
+		Format:       MMFormatList,
+		Templates:    DefaultTemplates(),
+		MaxPostSize:  mmDefaultMaxPostSize,
+		AttachFormat: MMAttachCSV,
+		client:       &http.Client{Timeout: 30 * time.Second},
+		channels:     &mmChannelCache{ids: make(map[string]string)},
+		sent:         &mmSentParts{keys: make(map[string]struct{})},
 	}
-	payload := map[string]string{
-		"text":     b.String(),
-		"username": "DomainWatcher",
+}
+
+func (m *Mattermost) Name() string {
+	return "mattermost"
+}
+
+// Retarget возвращает копию с другим каналом и/или форматом
+func (m *Mattermost) Retarget(t RouteTarget) (Notifier, error) {
+	c := *m
+	if t.Channel != "" {
+		// личные сообщения по @username через API так не найти, а вложения уходят через API
+		if m.AttachThreshold > 0 && strings.HasPrefix(t.Channel, "@") {
+			return nil, fmt.Errorf("mattermost channel %q: direct messages are not supported with attachments", t.Channel)
+		}
+		c.Channel = t.Channel
+	}
+	if t.Format != "" {
+		if !m.Templates.Has(m.Name(), TemplateDomains, t.Format) || !m.Templates.Has(m.Name(), TemplateLinks, t.Format) {
+			return nil, fmt.Errorf("unknown mattermost format %q: no %s and %s templates for it",
+				t.Format, templateName(TemplateDomains, t.Format), templateName(TemplateLinks, t.Format))
+		}
+		c.Format = t.Format
 	}
-	data, _ := json.Marshal(payload)
+	return &c, nil
+}
 
-	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewBuffer(data))
+func (m *Mattermost) attach(count int) bool {
+	return m.AttachThreshold > 0 && count > m.AttachThreshold
+}
+
+func (m *Mattermost) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	data := TemplateData{Domains: events, Count: len(events)}
+	if m.attach(len(events)) {
+		file, err := m.domainsFile(events)
+		if err != nil {
+			return err
+		}
+		return m.postWithFile(ctx, TemplateDomains, data, "domains", file)
+	}
+	text, err := m.Templates.Render(m.Name(), TemplateDomains, m.Format, data)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	return m.postParts(ctx, text, "DomainWatcher")
+}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+func (m *Mattermost) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	data := TemplateData{Links: events, Count: len(events)}
+	if m.attach(len(events)) {
+		file, err := m.linksFile(events)
+		if err != nil {
+			return err
+		}
+		return m.postWithFile(ctx, TemplateLinks, data, "social_links", file)
+	}
+	text, err := m.Templates.Render(m.Name(), TemplateLinks, m.Format, data)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	return m.postParts(ctx, text, "LinkWatcher")
+}
 
-	if resp.StatusCode/100 != 2 {
-		return fmt.Errorf("mm webhook returned %s", resp.Status)
+// postParts режет текст на посты не длиннее MaxPostSize. Части, опубликованные при прошлой неудачной
+// попытке той же пачки, пропускаются; память о них сбрасывается, когда ушли все части
+func (m *Mattermost) postParts(ctx context.Context, text, username string) error {
+	parts := splitPost(text, m.MaxPostSize)
+	if len(parts) == 1 {
+		return m.post(ctx, parts[0], username)
 	}
+
+	keys := make([]string, len(parts))
+	for i, part := range parts {
+		sum := sha256.Sum256([]byte(m.Webhook + "\x00" + m.Channel + "\x00" + part))
+		keys[i] = hex.EncodeToString(sum[:])
+	}
+	for i, part := range parts {
+		if m.sent.has(keys[i]) {
+			continue
+		}
+		if err := m.post(ctx, part, username); err != nil {
+			return fmt.Errorf("mattermost post %d/%d: %w", i+1, len(parts), err)
+		}
+		m.sent.add(keys[i])
+	}
+	m.sent.forget(keys)
 	return nil
 }
 
-func NotifyMMLinks(webhook string, links []*ent.SocialLink) error {
+func (s *mmSentParts) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.keys[key]
+	return ok
+}
+
+func (s *mmSentParts) add(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.keys) >= mmMaxSentParts {
+		clear(s.keys)
+	}
+	s.keys[key] = struct{}{}
+}
+
+func (s *mmSentParts) forget(keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		delete(s.keys, k)
+	}
+}
+
+// splitPost делит текст по строкам на части не длиннее max символов и нумерует их "[i/n] ".
+// Строка, которая сама не влезает в пост, режется на куски и переносится в следующие части без потерь
+func splitPost(text string, max int) []string {
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return []string{text}
+	}
+
+	// запас под префикс "[99/99] "
+	limit := max - 16
+	if limit < 1 {
+		limit = max
+	}
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		r := []rune(line)
+		for len(r) > limit {
+			lines = append(lines, string(r[:limit]))
+			r = r[limit:]
+		}
+		lines = append(lines, string(r))
+	}
+
+	var parts []string
 	var b strings.Builder
-	b.WriteString("**Появились новые ссылки:**\n")
-	for _, l := range links {
-		b.WriteString("- ")
-		b.WriteString(l.URL)
-		b.WriteString("   (")
-		b.WriteString(l.PageURL)
-		b.WriteString(")")
-		b.WriteString("\n")
+	size := 0
+	for _, line := range lines {
+		n := utf8.RuneCountInString(line)
+		if size > 0 && size+1+n > limit {
+			parts = append(parts, b.String())
+			b.Reset()
+			size = 0
+		}
+		if size > 0 {
+			b.WriteString("\n")
+			size++
+		}
+		b.WriteString(line)
+		size += n
 	}
-	payload := map[string]string{
-		"text":     b.String(),
-		"username": "LinkWatcher",
+	if size > 0 {
+		parts = append(parts, b.String())
 	}
-	data, _ := json.Marshal(payload)
 
-	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewBuffer(data))
+	for i := range parts {
+		parts[i] = fmt.Sprintf("[%d/%d] %s", i+1, len(parts), parts[i])
+	}
+	return parts
+}
+
+func (m *Mattermost) domainsFile(events []DomainEvent) ([]byte, error) {
+	if m.AttachFormat == MMAttachJSONL {
+		rows := make([]any, 0, len(events))
+		for _, d := range events {
+			rows = append(rows, d.data())
+		}
+		return jsonLines(rows)
+	}
+	records := [][]string{{"id", "landing_domain", "tld", "created_at", "severity", "tags"}}
+	for _, d := range events {
+		records = append(records, []string{
+			strconv.Itoa(d.ID), d.LandingDomain, d.TLD(), d.CreatedAt.Format(time.RFC3339), d.Severity, strings.Join(d.Tags, ","),
+		})
+	}
+	return csvBytes(records)
+}
+
+func (m *Mattermost) linksFile(events []LinkEvent) ([]byte, error) {
+	if m.AttachFormat == MMAttachJSONL {
+		rows := make([]any, 0, len(events))
+		for _, l := range events {
+			rows = append(rows, l.data())
+		}
+		return jsonLines(rows)
+	}
+	records := [][]string{{"id", "url", "platform", "page_url", "created_at", "severity", "tags"}}
+	for _, l := range events {
+		records = append(records, []string{
+			strconv.Itoa(l.ID), l.URL, l.PlatformName(), l.PageURL, l.CreatedAt.Format(time.RFC3339), l.Severity, strings.Join(l.Tags, ","),
+		})
+	}
+	return csvBytes(records)
+}
+
+func csvBytes(records [][]string) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func jsonLines(rows []any) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// postWithFile загружает файл в канал и публикует сводку с ним одним постом
+func (m *Mattermost) postWithFile(ctx context.Context, kind string, data TemplateData, prefix string, file []byte) error {
+	summary, err := m.Templates.Render(m.Name(), kind, "summary", data)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	name := fmt.Sprintf("%s-%s.%s", prefix, time.Now().UTC().Format("20060102-150405"), m.AttachFormat)
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	channelID, err := m.apiChannelID(ctx)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode/100 != 2 {
-		return fmt.Errorf("mm webhook returned %s", resp.Status)
+	fileID, err := m.uploadFile(ctx, channelID, name, file)
+	if err != nil {
+		return fmt.Errorf("mattermost upload %s: %w", name, err)
+	}
+	payload := map[string]any{
+		"channel_id": channelID,
+		"message":    summary,
+		"file_ids":   []string{fileID},
+	}
+	if _, err := postJSON(ctx, m.client, "mm api", m.APIURL+"/api/v4/posts", payload, m.authHeader()); err != nil {
+		return fmt.Errorf("mattermost post with %s: %w", name, err)
 	}
 	return nil
 }
+
+// apiChannelID возвращает ID канала для поста с файлом: ChannelID или ID канала Channel в той же команде
+func (m *Mattermost) apiChannelID(ctx context.Context) (string, error) {
+	if m.Channel == "" {
+		return m.ChannelID, nil
+	}
+	m.channels.mu.Lock()
+	defer m.channels.mu.Unlock()
+	if id, ok := m.channels.ids[m.Channel]; ok {
+		return id, nil
+	}
+
+	var base struct {
+		TeamID string `json:"team_id"`
+	}
+	if err := m.apiGet(ctx, "/api/v4/channels/"+url.PathEscape(m.ChannelID), &base); err != nil {
+		return "", fmt.Errorf("mattermost channel %s: %w", m.ChannelID, err)
+	}
+	var ch struct {
+		ID string `json:"id"`
+	}
+	name := strings.TrimPrefix(m.Channel, "~")
+	if err := m.apiGet(ctx, "/api/v4/teams/"+url.PathEscape(base.TeamID)+"/channels/name/"+url.PathEscape(name), &ch); err != nil {
+		return "", fmt.Errorf("mattermost channel %q: %w", m.Channel, err)
+	}
+	m.channels.ids[m.Channel] = ch.ID
+	return ch.ID, nil
+}
+
+func (m *Mattermost) apiGet(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.APIURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.APIToken)
+	data, err := doRequest(m.client, "mm api", req)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (m *Mattermost) uploadFile(ctx context.Context, channelID, name string, content []byte) (string, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("channel_id", channelID); err != nil {
+		return "", err
+	}
+	fw, err := mw.CreateFormFile("files", name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := fw.Write(content); err != nil {
+		return "", err
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.APIURL+"/api/v4/files", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+m.APIToken)
+
+	data, err := doRequest(m.client, "mm api", req)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		FileInfos []struct {
+			ID string `json:"id"`
+		} `json:"file_infos"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("decode upload response: %w", err)
+	}
+	if len(resp.FileInfos) == 0 {
+		return "", fmt.Errorf("upload response has no file_infos")
+	}
+	return resp.FileInfos[0].ID, nil
+}
+
+func (m *Mattermost) authHeader() http.Header {
+	return http.Header{"Authorization": {"Bearer " + m.APIToken}}
+}
+
+func (m *Mattermost) post(ctx context.Context, text, username string) error {
+	payload := map[string]string{
+		"text":     text,
+		"username": username,
+	}
+	if m.Channel != "" {
+		payload["channel"] = m.Channel
+	}
+	_, err := postJSON(ctx, m.client, "mm webhook", m.Webhook, payload, nil)
+	return err
+}
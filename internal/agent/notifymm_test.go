@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// fakeMMWebhook — входящий вебхук mattermost, который запоминает тексты постов и отвечает 500 на посты с номерами из fail
+type fakeMMWebhook struct {
+	texts []string
+	fail  map[int]bool // номер запроса, начиная с 1
+	calls int
+}
+
+func (f *fakeMMWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls++
+	if f.fail[f.calls] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.texts = append(f.texts, body.Text)
+}
+
+func TestSplitPostKeepsLongLines(t *testing.T) {
+	long := strings.Repeat("я", 250)
+	parts := splitPost("short\n"+long+"\ntail", 100)
+
+	var got strings.Builder
+	for i, part := range parts {
+		if n := utf8.RuneCountInString(part); n > 100 {
+			t.Errorf("part %d has %d runes", i, n)
+		}
+		_, text, _ := strings.Cut(part, "] ")
+		got.WriteString(strings.ReplaceAll(text, "\n", ""))
+	}
+	if want := "short" + long + "tail"; got.String() != want {
+		t.Errorf("parts lost text: got %d runes, want %d", utf8.RuneCountInString(got.String()), utf8.RuneCountInString(want))
+	}
+}
+
+func TestMattermostDoesNotRepostDeliveredParts(t *testing.T) {
+	f := &fakeMMWebhook{fail: map[int]bool{2: true}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	m := NewMattermost(srv.URL)
+	m.MaxPostSize = 200
+	events := make([]DomainEvent, 0, 20)
+	for i := range 20 {
+		events = append(events, DomainEvent{ID: i, LandingDomain: strings.Repeat("a", 20) + ".example.com"})
+	}
+
+	// вторая часть падает, повтор пачки должен дослать остаток без первой части
+	if err := m.NotifyDomains(context.Background(), events); err == nil {
+		t.Fatal("expected an error from the failed part")
+	}
+	if err := m.NotifyDomains(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, text := range f.texts {
+		if seen[text] {
+			t.Fatalf("part posted twice: %.40s...", text)
+		}
+		seen[text] = true
+	}
+	if n := len(f.texts); n < 3 || !strings.HasPrefix(f.texts[n-1], "[") {
+		t.Fatalf("got %d posts, want the batch split into parts", n)
+	}
+
+	// после полного успеха та же пачка публикуется заново
+	before := len(f.texts)
+	if err := m.NotifyDomains(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.texts) != 2*before {
+		t.Errorf("got %d new posts, want %d", len(f.texts)-before, before)
+	}
+}
@@ -0,0 +1,240 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
+	"github.com/zeshi09/go_web_parser_agent/internal/storage"
+)
+
+// OutboxWriter кладёт новые строки в outbox и двигает курсор потребителя в одной транзакции,
+// поэтому после падения курсор и очередь доставки всегда согласованы
+type OutboxWriter struct {
+	Client   *ent.Client
+	Consumer string
+	Sinks    []string
+}
+
+func (w *OutboxWriter) DeliverDomains(ctx context.Context, batch []*ent.Domain, next storage.Cursor) error {
+	entries := make([]storage.OutboxEntry, 0, len(batch))
+	for _, d := range batch {
+		payload, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, storage.OutboxEntry{
+			Kind:     outbox.KindDomain,
+			EntityID: d.ID,
+			Key:      outboxKey(outbox.KindDomain, d.ID),
+			Payload:  string(payload),
+		})
+	}
+	return w.enqueue(ctx, storage.StreamDomains, entries, next)
+}
+
+func (w *OutboxWriter) DeliverLinks(ctx context.Context, batch []*ent.SocialLink, next storage.Cursor) error {
+	entries := make([]storage.OutboxEntry, 0, len(batch))
+	for _, l := range batch {
+		payload, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, storage.OutboxEntry{
+			Kind:     outbox.KindSocialLink,
+			EntityID: l.ID,
+			Key:      outboxKey(outbox.KindSocialLink, l.ID),
+			Payload:  string(payload),
+		})
+	}
+	return w.enqueue(ctx, storage.StreamLinks, entries, next)
+}
+
+func (w *OutboxWriter) enqueue(ctx context.Context, stream string, entries []storage.OutboxEntry, next storage.Cursor) error {
+	return storage.WithTx(ctx, w.Client, func(tx *ent.Tx) error {
+		n, err := storage.EnqueueTx(ctx, tx, w.Consumer, w.Sinks, entries)
+		if err != nil {
+			return fmt.Errorf("enqueue %s: %w", stream, err)
+		}
+		if err := storage.SaveCursorTx(ctx, tx, w.Consumer, stream, next); err != nil {
+			return fmt.Errorf("save %s cursor: %w", stream, err)
+		}
+		log.Debug().Str("stream", stream).Int("enqueued", n).Msg("outbox")
+		return nil
+	})
+}
+
+// ключ идемпотентности: тип сущности и её ID в таблице парсера
+func outboxKey(kind outbox.Kind, id int) string {
+	return string(kind) + ":" + strconv.Itoa(id)
+}
+
+// Dispatcher забирает записи из outbox и доставляет их в sink'и с повторами и экспоненциальной задержкой.
+// Доставка at-least-once: если процесс упадёт между ответом sink'а и отметкой delivered,
+// запись уйдёт повторно с тем же ключом идемпотентности
+type Dispatcher struct {
+	Client   *ent.Client
+	Consumer string
+	Sinks    map[string]Notifier
+
+	Interval    time.Duration // как часто проверять очередь
+	BatchSize   int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	MaxAttempts int           // 0 — повторять бесконечно
+	Retention   time.Duration // сколько хранить доставленные записи
+}
+
+func NewDispatcher(client *ent.Client, consumer string, sinks map[string]Notifier) *Dispatcher {
+	return &Dispatcher{
+		Client:      client,
+		Consumer:    consumer,
+		Sinks:       sinks,
+		Interval:    2 * time.Second,
+		BatchSize:   500,
+		BaseBackoff: 5 * time.Second,
+		MaxBackoff:  10 * time.Minute,
+		MaxAttempts: 0,
+		Retention:   7 * 24 * time.Hour,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	t := time.NewTicker(d.Interval)
+	defer t.Stop()
+
+	for {
+		for {
+			n, err := d.dispatchOnce(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("outbox dispatch failed")
+				break
+			}
+			if n < d.BatchSize {
+				break
+			}
+		}
+
+		if d.Retention > 0 {
+			if n, err := storage.PurgeDelivered(ctx, d.Client, time.Now().Add(-d.Retention)); err != nil {
+				log.Error().Err(err).Msg("outbox purge failed")
+			} else if n > 0 {
+				log.Debug().Int("purged", n).Msg("outbox")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// группа записей одного sink'а и одного типа, отправляется одной пачкой
+type outboxGroup struct {
+	sink string
+	kind outbox.Kind
+	rows []*ent.Outbox
+}
+
+func (d *Dispatcher) dispatchOnce(ctx context.Context) (int, error) {
+	rows, err := storage.DueOutbox(ctx, d.Client, d.Consumer, d.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// группируем с сохранением порядка первого появления
+	var groups []*outboxGroup
+	index := make(map[string]*outboxGroup)
+	for _, r := range rows {
+		k := r.Sink + "/" + string(r.Kind)
+		g, ok := index[k]
+		if !ok {
+			g = &outboxGroup{sink: r.Sink, kind: r.Kind}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		g.rows = append(g.rows, r)
+	}
+
+	for _, g := range groups {
+		if err := d.deliver(ctx, g); err != nil {
+			log.Error().Err(err).Str("sink", g.sink).Int("rows", len(g.rows)).Msg("outbox delivery failed")
+			d.retry(ctx, g.rows, err)
+			continue
+		}
+
+		ids := make([]int, 0, len(g.rows))
+		for _, r := range g.rows {
+			ids = append(ids, r.ID)
+		}
+		if err := storage.MarkDelivered(ctx, d.Client, ids); err != nil {
+			return len(rows), err
+		}
+	}
+	return len(rows), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, g *outboxGroup) error {
+	sink, ok := d.Sinks[g.sink]
+	if !ok {
+		return fmt.Errorf("unknown sink %q", g.sink)
+	}
+
+	switch g.kind {
+	case outbox.KindDomain:
+		batch := make([]*ent.Domain, 0, len(g.rows))
+		for _, r := range g.rows {
+			var dom ent.Domain
+			if err := json.Unmarshal([]byte(r.Payload), &dom); err != nil {
+				return fmt.Errorf("decode outbox %d: %w", r.ID, err)
+			}
+			batch = append(batch, &dom)
+		}
+		return sink.NotifyDomains(ctx, DomainEvents(batch))
+	case outbox.KindSocialLink:
+		batch := make([]*ent.SocialLink, 0, len(g.rows))
+		for _, r := range g.rows {
+			var l ent.SocialLink
+			if err := json.Unmarshal([]byte(r.Payload), &l); err != nil {
+				return fmt.Errorf("decode outbox %d: %w", r.ID, err)
+			}
+			batch = append(batch, &l)
+		}
+		return sink.NotifyLinks(ctx, LinkEvents(batch))
+	default:
+		return fmt.Errorf("unknown outbox kind %q", g.kind)
+	}
+}
+
+func (d *Dispatcher) retry(ctx context.Context, rows []*ent.Outbox, cause error) {
+	for _, r := range rows {
+		attempts := r.Attempts + 1
+		failed := d.MaxAttempts > 0 && attempts >= d.MaxAttempts
+		if err := storage.MarkRetry(ctx, d.Client, r, time.Now().Add(d.backoff(attempts)), failed, cause); err != nil {
+			log.Error().Err(err).Int("outbox_id", r.ID).Msg("outbox retry update failed")
+			continue
+		}
+		if failed {
+			log.Error().Int("outbox_id", r.ID).Str("key", r.Key).Msg("outbox delivery gave up")
+		}
+	}
+}
+
+// задержка удваивается с каждой попыткой, но не больше MaxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zeshi09/go_web_parser_agent/pkg/redisstream"
+)
+
+// RedisStreams добавляет события в стримы через XADD с обрезкой MAXLEN ~ N.
+// Пачка уходит одним pipeline и считается доставленной, только если все XADD прошли.
+// Читать стримы можно через pkg/redisstream
+type RedisStreams struct {
+	DomainStream string
+	LinkStream   string
+	MaxLen       int64 // 0 — без обрезки
+	client       *redis.Client
+}
+
+func NewRedisStreams(url, domainStream, linkStream string, maxLen int64) (*RedisStreams, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("redis url: %w", err)
+	}
+	return &RedisStreams{
+		DomainStream: domainStream,
+		LinkStream:   linkStream,
+		MaxLen:       maxLen,
+		client:       redis.NewClient(opts),
+	}, nil
+}
+
+func (r *RedisStreams) Name() string {
+	return "redis"
+}
+
+func (r *RedisStreams) Close() error {
+	return r.client.Close()
+}
+
+func (r *RedisStreams) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	envs := make([]envelope, 0, len(events))
+	for _, d := range events {
+		envs = append(envs, domainEnvelope(d))
+	}
+	return r.add(ctx, r.DomainStream, envs)
+}
+
+func (r *RedisStreams) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	envs := make([]envelope, 0, len(events))
+	for _, l := range events {
+		envs = append(envs, linkEnvelope(l))
+	}
+	return r.add(ctx, r.LinkStream, envs)
+}
+
+func (r *RedisStreams) add(ctx context.Context, stream string, envs []envelope) error {
+	if len(envs) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for _, env := range envs {
+		payload, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: r.MaxLen,
+			Approx: true,
+			Values: map[string]any{
+				redisstream.FieldID:      env.ID,
+				redisstream.FieldType:    env.Type,
+				redisstream.FieldPayload: payload,
+			},
+		})
+	}
+
+	cmds, err := pipe.Exec(ctx)
+	if err != nil {
+		for _, cmd := range cmds {
+			if cmd.Err() != nil {
+				return fmt.Errorf("xadd %s: %w", stream, cmd.Err())
+			}
+		}
+		return err
+	}
+	return nil
+}
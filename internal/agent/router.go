@@ -0,0 +1,368 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// типы сущностей в правилах маршрутизации
+const (
+	RouteTypeDomain     = "domain"
+	RouteTypeSocialLink = "sociallink"
+)
+
+// RoutingConfig — правила маршрутизации из ROUTES_FILE (JSON):
+//
+//	{
+//	  "routes": [
+//	    {
+//	      "name": "telegram-links",
+//	      "match": {"type": "sociallink", "platform": ["t.me"], "page_host": ["example.com"]},
+//	      "sinks": ["mattermost"],
+//	      "channel": "tg-watch",
+//	      "format": "table",
+//	      "continue": false
+//	    }
+//	  ],
+//	  "default": {"sinks": ["mattermost", "email"]}
+//	}
+//
+// Маршруты проверяются по порядку, событие уходит по первому совпавшему, а при "continue": true —
+// и по следующим. Событие без единого совпадения уходит по default; без default оно отбрасывается
+type RoutingConfig struct {
+	Routes  []Route `json:"routes"`
+	Default *Route  `json:"default"`
+}
+
+// Route — маршрут: условие и sink'и (по Name()), которые получат совпавшие события.
+// Channel и Format применяются к sink'ам, которые их поддерживают, см. Retargeter
+type Route struct {
+	Name     string     `json:"name"`
+	Match    RouteMatch `json:"match"`
+	Sinks    []string   `json:"sinks"`
+	Channel  string     `json:"channel"`
+	Format   string     `json:"format"`
+	Continue bool       `json:"continue"`
+}
+
+// RouteMatch — условия маршрута. Пустое поле не ограничивает, несколько значений в поле
+// работают как ИЛИ, разные поля — как И. Сравнение без учёта регистра
+type RouteMatch struct {
+	Type     string   `json:"type"`      // domain или sociallink
+	Platform []string `json:"platform"`  // SocialLink.domain (или хост ссылки), только для ссылок
+	TLD      []string `json:"tld"`       // зона landing_domain, только для доменов
+	Keyword  []string `json:"keyword"`   // подстрока landing_domain, url или page_url
+	PageHost []string `json:"page_host"` // хост page_url или его поддомен, только для ссылок
+}
+
+// RouteTarget — куда и в каком виде маршрут отправляет события конкретного sink'а
+type RouteTarget struct {
+	Channel string
+	Format  string
+}
+
+// Retargeter — sink, умеющий отправлять в другой канал или в другом формате.
+// Retarget возвращает копию sink'а с теми же учётными данными
+type Retargeter interface {
+	Retarget(t RouteTarget) (Notifier, error)
+}
+
+func LoadRoutingConfig(path string) (*RoutingConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg RoutingConfig
+	if err := decodeStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i, r := range cfg.Routes {
+		if err := r.Match.validate(); err != nil {
+			return nil, fmt.Errorf("%s: route %q (#%d): %w", path, r.Name, i+1, err)
+		}
+	}
+	if cfg.Default != nil {
+		if err := cfg.Default.Match.validate(); err != nil {
+			return nil, fmt.Errorf("%s: default route: %w", path, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// decodeStrict разбирает JSON конфига, отклоняя неизвестные поля: опечатка в ключе условия
+// иначе молча превратила бы маршрут или фильтр в "совпадает со всем"
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the top-level object")
+	}
+	return nil
+}
+
+// validate проверяет тип сущности: с неизвестным типом маршрут молча не совпадал бы ни с чем
+func (m RouteMatch) validate() error {
+	switch strings.ToLower(m.Type) {
+	case "", RouteTypeDomain, RouteTypeSocialLink:
+		return nil
+	}
+	return fmt.Errorf("unknown match type %q, want %s or %s", m.Type, RouteTypeDomain, RouteTypeSocialLink)
+}
+
+func (m RouteMatch) matchDomain(e DomainEvent) bool {
+	if m.Type != "" && !strings.EqualFold(m.Type, RouteTypeDomain) {
+		return false
+	}
+	if len(m.Platform) > 0 || len(m.PageHost) > 0 {
+		return false
+	}
+	if len(m.TLD) > 0 && !containsFold(m.TLD, e.TLD()) {
+		return false
+	}
+	if len(m.Keyword) > 0 && !containsKeyword(m.Keyword, e.LandingDomain) {
+		return false
+	}
+	return true
+}
+
+func (m RouteMatch) matchLink(e LinkEvent) bool {
+	if m.Type != "" && !strings.EqualFold(m.Type, RouteTypeSocialLink) {
+		return false
+	}
+	if len(m.TLD) > 0 {
+		return false
+	}
+	if len(m.Platform) > 0 && !containsFold(m.Platform, strings.TrimPrefix(e.PlatformName(), "www.")) {
+		return false
+	}
+	if len(m.PageHost) > 0 && !matchHost(m.PageHost, e.PageHost()) {
+		return false
+	}
+	if len(m.Keyword) > 0 && !containsKeyword(m.Keyword, e.URL, e.PageURL) {
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimPrefix(v, "."), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKeyword(keywords []string, fields ...string) bool {
+	for _, f := range fields {
+		f = strings.ToLower(f)
+		for _, k := range keywords {
+			if strings.Contains(f, strings.ToLower(k)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchHost: host совпадает с одним из хостов списка или является его поддоменом
+func matchHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		h = strings.TrimPrefix(strings.ToLower(h), "www.")
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeTable — общая для всех sink'ов таблица маршрутов
+type routeTable struct {
+	routes []Route
+	def    *Route
+}
+
+// domainRoutes возвращает индексы маршрутов события; -1 — default
+func (t *routeTable) domainRoutes(e DomainEvent) []int {
+	var out []int
+	for i, r := range t.routes {
+		if r.Match.matchDomain(e) {
+			out = append(out, i)
+			if !r.Continue {
+				break
+			}
+		}
+	}
+	if len(out) == 0 && t.def != nil {
+		out = append(out, -1)
+	}
+	return out
+}
+
+func (t *routeTable) linkRoutes(e LinkEvent) []int {
+	var out []int
+	for i, r := range t.routes {
+		if r.Match.matchLink(e) {
+			out = append(out, i)
+			if !r.Continue {
+				break
+			}
+		}
+	}
+	if len(out) == 0 && t.def != nil {
+		out = append(out, -1)
+	}
+	return out
+}
+
+// routedTarget — экземпляр sink'а для одного маршрута
+type routedTarget struct {
+	route    int
+	notifier Notifier
+}
+
+// routedSink отдаёт sink'у только те события, которые маршруты направили в него.
+// Имя остаётся именем исходного sink'а, поэтому outbox работает с ним как с обычным sink'ом
+type routedSink struct {
+	base      Notifier
+	table     *routeTable
+	targets   []routedTarget
+	delivered *deliveryLog // по индексу в targets
+}
+
+// RouteNotifiers оборачивает sink'и правилами маршрутизации. Sink'и, упомянутые в правилах, заменяются
+// обёртками; sink'и, которых нет ни в одном маршруте, возвращаются как есть и получают все события
+func RouteNotifiers(cfg *RoutingConfig, sinks []Notifier) ([]Notifier, error) {
+	byName := make(map[string]Notifier, len(sinks))
+	for _, n := range sinks {
+		byName[n.Name()] = n
+	}
+
+	table := &routeTable{routes: cfg.Routes, def: cfg.Default}
+	wrapped := make(map[string]*routedSink)
+
+	add := func(idx int, r Route) error {
+		if len(r.Sinks) == 0 {
+			return fmt.Errorf("route %q has no sinks", r.Name)
+		}
+		for _, name := range r.Sinks {
+			base, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("route %q: sink %q is not configured", r.Name, name)
+			}
+			target := base
+			if r.Channel != "" || r.Format != "" {
+				rt, ok := base.(Retargeter)
+				if !ok {
+					return fmt.Errorf("route %q: sink %q does not support channel or format", r.Name, name)
+				}
+				var err error
+				if target, err = rt.Retarget(RouteTarget{Channel: r.Channel, Format: r.Format}); err != nil {
+					return fmt.Errorf("route %q: %w", r.Name, err)
+				}
+			}
+			rs, ok := wrapped[name]
+			if !ok {
+				rs = &routedSink{base: base, table: table}
+				wrapped[name] = rs
+			}
+			rs.targets = append(rs.targets, routedTarget{route: idx, notifier: target})
+		}
+		return nil
+	}
+
+	for i, r := range cfg.Routes {
+		if r.Name == "" {
+			cfg.Routes[i].Name = fmt.Sprintf("#%d", i+1)
+			r.Name = cfg.Routes[i].Name
+		}
+		if err := add(i, r); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Default != nil {
+		if cfg.Default.Name == "" {
+			cfg.Default.Name = "default"
+		}
+		if err := add(-1, *cfg.Default); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]Notifier, 0, len(sinks))
+	var unrouted []string
+	for _, n := range sinks {
+		if rs, ok := wrapped[n.Name()]; ok {
+			rs.delivered = newDeliveryLog(len(rs.targets))
+			out = append(out, rs)
+			continue
+		}
+		out = append(out, n)
+		unrouted = append(unrouted, n.Name())
+	}
+	if len(unrouted) > 0 {
+		log.Warn().Strs("sinks", unrouted).Msg("sinks not referenced in routes receive all events")
+	}
+	return out, nil
+}
+
+func (s *routedSink) Name() string {
+	return s.base.Name()
+}
+
+func (s *routedSink) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	return routeBatch(s, events, s.table.domainRoutes, DomainEvent.EventID, func(n Notifier, batch []DomainEvent) error {
+		return n.NotifyDomains(ctx, batch)
+	})
+}
+
+func (s *routedSink) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	return routeBatch(s, events, s.table.linkRoutes, LinkEvent.EventID, func(n Notifier, batch []LinkEvent) error {
+		return n.NotifyLinks(ctx, batch)
+	})
+}
+
+// routeBatch раскладывает события по маршрутам и отправляет каждому маршруту его часть.
+// Все маршруты одного sink'а выглядят для Fanout и outbox как один sink, поэтому при частичном
+// сбое routedSink сам помнит, что каждый маршрут уже принял, и при повторе шлёт только остальное
+func routeBatch[E any](s *routedSink, events []E, routes func(E) []int, id func(E) string, notify func(Notifier, []E) error) error {
+	groups := make(map[int][]E)
+	for _, e := range events {
+		for _, r := range routes(e) {
+			groups[r] = append(groups[r], e)
+		}
+	}
+	var errs []error
+	for i, t := range s.targets {
+		batch := pendingFor(s.delivered, i, groups[t.route], id)
+		if len(batch) == 0 {
+			continue
+		}
+		if err := notify(t.notifier, batch); err != nil {
+			errs = append(errs, fmt.Errorf("route %s: %w", s.table.name(t.route), err))
+			continue
+		}
+		markDelivered(s.delivered, i, batch, id)
+	}
+	if len(errs) == 0 {
+		forgetDelivered(s.delivered, events, id)
+	}
+	return errors.Join(errs...)
+}
+
+func (t *routeTable) name(idx int) string {
+	if idx < 0 {
+		return t.def.Name
+	}
+	return t.routes[idx].Name
+}
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeChannels — sink с каналами: запоминает, какие домены пришли в какой канал, канал "bad" всегда падает
+type fakeChannels struct {
+	channel string
+	got     map[string][]int
+}
+
+func (f *fakeChannels) Name() string {
+	return "chat"
+}
+
+func (f *fakeChannels) Retarget(t RouteTarget) (Notifier, error) {
+	return &fakeChannels{channel: t.Channel, got: f.got}, nil
+}
+
+func (f *fakeChannels) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	if f.channel == "bad" {
+		return errors.New("channel not found")
+	}
+	for _, e := range events {
+		f.got[f.channel] = append(f.got[f.channel], e.ID)
+	}
+	return nil
+}
+
+func (f *fakeChannels) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	return nil
+}
+
+func TestRoutedSinkDoesNotResendToHealthyRoutes(t *testing.T) {
+	sink := &fakeChannels{got: make(map[string][]int)}
+	cfg := &RoutingConfig{Routes: []Route{
+		{Name: "good", Sinks: []string{"chat"}, Channel: "good", Continue: true},
+		{Name: "bad", Sinks: []string{"chat"}, Channel: "bad"},
+	}}
+	routed, err := RouteNotifiers(cfg, []Notifier{sink})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fanout := NewFanout(routed)
+
+	batch := []DomainEvent{{ID: 1, LandingDomain: "a.example.com"}, {ID: 2, LandingDomain: "b.example.com"}}
+	// так цикл сканирования повторяет пачку, пока сломанный канал не починят
+	for range 5 {
+		if err := fanout.NotifyDomains(context.Background(), batch); err == nil {
+			t.Fatal("expected an error from the bad route")
+		}
+	}
+	if got := sink.got["good"]; len(got) != 2 {
+		t.Fatalf("good channel received %v, want each event once", got)
+	}
+
+	// после полного успеха память о пачке сбрасывается, и новая пачка с теми же ID снова доставляется
+	cfg.Routes[1].Match.Type = RouteTypeSocialLink
+	routed, err = RouteNotifiers(cfg, []Notifier{sink})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := routed[0].(*routedSink)
+	for range 2 {
+		if err := rs.NotifyDomains(context.Background(), batch[:1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := sink.got["good"]; len(got) != 4 {
+		t.Fatalf("good channel received %v, want the batch again after full success", got)
+	}
+}
+
+func TestLoadRoutingConfigRejectsTypos(t *testing.T) {
+	for name, text := range map[string]string{
+		"unknown key":  `{"routes": [{"match": {"platforms": ["t.me"]}, "sinks": ["chat"]}]}`,
+		"unknown type": `{"routes": [{"match": {"type": "link"}, "sinks": ["chat"]}]}`,
+	} {
+		path := filepath.Join(t.TempDir(), "routes.json")
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadRoutingConfig(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -2,13 +2,40 @@ package agent
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/zeshi09/go_web_parser_agent/ent"
 	"github.com/zeshi09/go_web_parser_agent/internal/storage"
 )
 
-func ScanAndNotifyDomains(ctx context.Context, client *ent.Client, c *storage.Cursor, webhook string, notify bool) error {
+// Delivery решает, что делать с новой пачкой строк: отправить сразу или положить в outbox.
+// next — позиция курсора после пачки, её может сохранить атомарно с доставкой
+type Delivery interface {
+	DeliverDomains(ctx context.Context, batch []*ent.Domain, next storage.Cursor) error
+	DeliverLinks(ctx context.Context, batch []*ent.SocialLink, next storage.Cursor) error
+}
+
+// Direct отправляет пачку в Notifier прямо из цикла сканирования
+type Direct struct {
+	Notifier Notifier
+}
+
+func (d Direct) DeliverDomains(ctx context.Context, batch []*ent.Domain, _ storage.Cursor) error {
+	return d.Notifier.NotifyDomains(ctx, DomainEvents(batch))
+}
+
+func (d Direct) DeliverLinks(ctx context.Context, batch []*ent.SocialLink, _ storage.Cursor) error {
+	return d.Notifier.NotifyLinks(ctx, LinkEvents(batch))
+}
+
+func ScanAndNotifyDomains(ctx context.Context, client *ent.Client, c *storage.Cursor, d Delivery, notify bool, lookback time.Duration) error {
+	if lookback > 0 {
+		if err := rescanLateDomains(ctx, client, c, d, notify, lookback); err != nil {
+			return err
+		}
+	}
+
 	total := 0
 	for {
 		batch, err := storage.CheckNewDomains(ctx, client, *c)
@@ -19,27 +46,53 @@ func ScanAndNotifyDomains(ctx context.Context, client *ent.Client, c *storage.Cu
 			break
 		}
 
+		// запоминаем строки до доставки: если она упадёт, курсор не сдвинется
+		// и основной проход всё равно перечитает пачку
+		if lookback > 0 {
+			for _, row := range batch {
+				c.Remember(row.ID, row.CreatedAt)
+			}
+		}
+
+		last := batch[len(batch)-1]
+		next := storage.Cursor{LastCreatedAt: last.CreatedAt, LastID: last.ID, Recent: c.Recent, RecentSince: c.RecentSince}
 		if notify {
-			if err := NotifyMMDomains(webhook, batch); err != nil {
+			if err := d.DeliverDomains(ctx, batch, next); err != nil {
 				return err
 			}
 		}
 
-		last := batch[len(batch)-1]
 		c.LastCreatedAt = last.CreatedAt
 		c.LastID = last.ID
+		if lookback > 0 {
+			boundRecent(c, lookback, storage.StreamDomains)
+		}
 
 		total += len(batch)
 		if len(batch) < storage.PageSize {
 			break
 		}
+
+		// полная страница: скорее всего идёт первичная синхронизация или догоняем отставание
+		log.Info().
+			Int("scanned_domains", total).
+			Time("cursor_created_at", c.LastCreatedAt).
+			Int("cursor_id", c.LastID).
+			Msg("sync in progress")
 	}
 	if total > 0 {
 		log.Info().Int("new_domains", total).Msg("processed")
 	}
 	return nil
 }
-func ScanAndNotifyLinks(ctx context.Context, client *ent.Client, c *storage.Cursor, webhook string, notify bool) error {
+
+func ScanAndNotifyLinks(ctx context.Context, client *ent.Client, c *storage.Cursor, d Delivery, notify bool, lookback time.Duration) error {
+	if lookback > 0 {
+		if err := rescanLateLinks(ctx, client, c, d, notify, lookback); err != nil {
+			return err
+		}
+	}
+
 	total := 0
 	for {
 		batch, err := storage.CheckNewSocialLinks(ctx, client, *c)
@@ -50,23 +103,155 @@ func ScanAndNotifyLinks(ctx context.Context, client *ent.Client, c *storage.Curs
 			break
 		}
 
+		// запоминаем строки до доставки: если она упадёт, курсор не сдвинется
+		// и основной проход всё равно перечитает пачку
+		if lookback > 0 {
+			for _, row := range batch {
+				c.Remember(row.ID, row.CreatedAt)
+			}
+		}
+
+		last := batch[len(batch)-1]
+		next := storage.Cursor{LastCreatedAt: last.CreatedAt, LastID: last.ID, Recent: c.Recent, RecentSince: c.RecentSince}
 		if notify {
-			if err := NotifyMMLinks(webhook, batch); err != nil {
+			if err := d.DeliverLinks(ctx, batch, next); err != nil {
 				return err
 			}
 		}
 
-		last := batch[len(batch)-1]
 		c.LastCreatedAt = last.CreatedAt
 		c.LastID = last.ID
+		if lookback > 0 {
+			boundRecent(c, lookback, storage.StreamLinks)
+		}
 
 		total += len(batch)
 		if len(batch) < storage.PageSize {
 			break
 		}
+
+		// полная страница: скорее всего идёт первичная синхронизация или догоняем отставание
+		log.Info().
+			Int("scanned_links", total).
+			Time("cursor_created_at", c.LastCreatedAt).
+			Int("cursor_id", c.LastID).
+			Msg("sync in progress")
 	}
 	if total > 0 {
 		log.Info().Int("new_links", total).Msg("processed")
 	}
 	return nil
 }
+
+// rescanLateDomains досылает домены, которые появились позади курсора внутри окна look-back.
+// Окно читается страницами, как и основной проход. При первом включении окна (Recent == nil)
+// только запоминает уже лежащие в окне строки, чтобы не разослать их повторно
+func rescanLateDomains(ctx context.Context, client *ent.Client, c *storage.Cursor, d Delivery, notify bool, lookback time.Duration) error {
+	seeding := c.Recent == nil
+	if seeding {
+		c.Recent = make(map[int]time.Time)
+	}
+	if c.LastCreatedAt.IsZero() && c.LastID == 0 {
+		return nil
+	}
+
+	total := 0
+	after := storage.Cursor{LastCreatedAt: c.LateSince(lookback)}
+	for {
+		page, err := storage.CheckLateDomains(ctx, client, *c, after)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		var late []*ent.Domain
+		for _, row := range page {
+			if !c.Seen(row.ID) {
+				late = append(late, row)
+			}
+		}
+		if notify && !seeding && len(late) > 0 {
+			if err := d.DeliverDomains(ctx, late, *c); err != nil {
+				return err
+			}
+		}
+		for _, row := range late {
+			c.Remember(row.ID, row.CreatedAt)
+		}
+		total += len(late)
+
+		if len(page) < storage.PageSize {
+			break
+		}
+		last := page[len(page)-1]
+		after = storage.Cursor{LastCreatedAt: last.CreatedAt, LastID: last.ID}
+	}
+	if notify && !seeding && total > 0 {
+		log.Warn().Int("late_domains", total).Msg("processed rows inserted behind the cursor")
+	}
+	boundRecent(c, lookback, storage.StreamDomains)
+	return nil
+}
+
+// rescanLateLinks — то же для ссылок, см. rescanLateDomains
+func rescanLateLinks(ctx context.Context, client *ent.Client, c *storage.Cursor, d Delivery, notify bool, lookback time.Duration) error {
+	seeding := c.Recent == nil
+	if seeding {
+		c.Recent = make(map[int]time.Time)
+	}
+	if c.LastCreatedAt.IsZero() && c.LastID == 0 {
+		return nil
+	}
+
+	total := 0
+	after := storage.Cursor{LastCreatedAt: c.LateSince(lookback)}
+	for {
+		page, err := storage.CheckLateSocialLinks(ctx, client, *c, after)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		var late []*ent.SocialLink
+		for _, row := range page {
+			if !c.Seen(row.ID) {
+				late = append(late, row)
+			}
+		}
+		if notify && !seeding && len(late) > 0 {
+			if err := d.DeliverLinks(ctx, late, *c); err != nil {
+				return err
+			}
+		}
+		for _, row := range late {
+			c.Remember(row.ID, row.CreatedAt)
+		}
+		total += len(late)
+
+		if len(page) < storage.PageSize {
+			break
+		}
+		last := page[len(page)-1]
+		after = storage.Cursor{LastCreatedAt: last.CreatedAt, LastID: last.ID}
+	}
+	if notify && !seeding && total > 0 {
+		log.Warn().Int("late_links", total).Msg("processed rows inserted behind the cursor")
+	}
+	boundRecent(c, lookback, storage.StreamLinks)
+	return nil
+}
+
+// boundRecent выкидывает строки, вышедшие из окна look-back, и держит Recent в пределах storage.MaxRecent.
+// Если строк в окне больше, окно сужается: опоздавшие строки старше RecentSince уже не найдутся
+func boundRecent(c *storage.Cursor, lookback time.Duration, stream string) {
+	c.Prune(lookback)
+	if c.Trim(storage.MaxRecent) {
+		log.Warn().
+			Str("stream", stream).
+			Int("max_recent", storage.MaxRecent).
+			Time("window_start", c.RecentSince).
+			Msg("look-back window holds too many rows, shrinking it; lower LOOKBACK")
+	}
+}
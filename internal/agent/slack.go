@@ -0,0 +1,197 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ограничения Block Kit для входящих вебхуков
+const (
+	slackMaxBlocks      = 50
+	slackMaxSectionText = 3000
+	slackMaxHeaderText  = 150
+	slackMaxMessageText = 40000 // slack обрезает более длинные сообщения
+)
+
+// Slack шлёт уведомления во входящий вебхук slack в виде Block Kit сообщений.
+// Большие пачки режутся на несколько сообщений с учётом лимитов на блоки и длину текста.
+// Строка на событие берётся из шаблонов slack/<kind>.item, заголовки — из подписей Templates
+type Slack struct {
+	Webhook   string
+	Templates *Templates
+	client    *http.Client
+}
+
+func NewSlack(webhook string) *Slack {
+	return &Slack{
+		Webhook:   webhook,
+		Templates: DefaultTemplates(),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+func (s *Slack) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	lines := make([]string, 0, len(events))
+	for _, d := range events {
+		line, err := s.Templates.Render(s.Name(), TemplateDomains, TemplateItem, TemplateData{Domain: d, Count: 1})
+		if err != nil {
+			return err
+		}
+		lines = append(lines, slackFit(line, "• "+d.LandingDomain))
+	}
+	return s.send(ctx, s.Templates.Label(s.Name(), "domains.title", len(events)), lines)
+}
+
+func (s *Slack) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	lines := make([]string, 0, len(events))
+	for _, l := range events {
+		line, err := s.Templates.Render(s.Name(), TemplateLinks, TemplateItem, TemplateData{Link: l, Count: 1})
+		if err != nil {
+			return err
+		}
+		plain := "• " + l.URL
+		if p := l.PlatformName(); p != "" {
+			plain += " (" + p + ")"
+		}
+		lines = append(lines, slackFit(line, plain+"\n      ↳ "+l.PageURL))
+	}
+	return s.send(ctx, s.Templates.Label(s.Name(), "links.title", len(events)), lines)
+}
+
+// send раскладывает строки по section-блокам и сообщениям
+func (s *Slack) send(ctx context.Context, title string, lines []string) error {
+	messages := slackPack(title, lines)
+	for i, msg := range messages {
+		if err := s.post(ctx, msg); err != nil {
+			return fmt.Errorf("slack message %d/%d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// slackFit возвращает разметку строки, если она влезает в section-блок, а иначе — обрезанный текст
+// без <url|текст>: обрезать готовую разметку нельзя, можно разрезать ссылку пополам.
+// Лимиты Slack считаются в символах, экранирование может удлинить текст, поэтому режем до попадания в лимит
+func slackFit(markup, plain string) string {
+	if utf8.RuneCountInString(markup) <= slackMaxSectionText {
+		return markup
+	}
+	n := slackMaxSectionText
+	for {
+		text := slackEscape(truncateText(plain, n))
+		size := utf8.RuneCountInString(text)
+		if size <= slackMaxSectionText || n == 1 {
+			return text
+		}
+		// укорачиваем пропорционально перебору, но хотя бы на символ
+		n = max(1, min(n-1, n*slackMaxSectionText/size))
+	}
+}
+
+// slackPack раскладывает строки (каждая уже не длиннее section-блока, см. slackFit) по блокам и сообщениям;
+// длины считаются в символах, как их считает Slack
+func slackPack(title string, lines []string) []slackMessage {
+	header := slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateText(title, slackMaxHeaderText)}}
+
+	var messages []slackMessage
+	cur := slackMessage{Text: title, Blocks: []slackBlock{header}}
+	curLen := 0
+	var section strings.Builder
+	sectionLen := 0
+
+	flushSection := func() {
+		if sectionLen == 0 {
+			return
+		}
+		if len(cur.Blocks) == slackMaxBlocks || curLen+sectionLen > slackMaxMessageText {
+			messages = append(messages, cur)
+			cur = slackMessage{Text: title, Blocks: []slackBlock{header}}
+			curLen = 0
+		}
+		cur.Blocks = append(cur.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: section.String()}})
+		curLen += sectionLen
+		section.Reset()
+		sectionLen = 0
+	}
+
+	for _, line := range lines {
+		n := utf8.RuneCountInString(line)
+		if sectionLen > 0 && sectionLen+1+n > slackMaxSectionText {
+			flushSection()
+		}
+		if sectionLen > 0 {
+			section.WriteString("\n")
+			sectionLen++
+		}
+		section.WriteString(line)
+		sectionLen += n
+	}
+	flushSection()
+	if len(cur.Blocks) > 1 {
+		messages = append(messages, cur)
+	}
+
+	// при нескольких сообщениях нумеруем заголовки
+	if len(messages) > 1 {
+		for i := range messages {
+			part := fmt.Sprintf("%s (%d/%d)", title, i+1, len(messages))
+			messages[i].Text = part
+			messages[i].Blocks[0] = slackBlock{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateText(part, slackMaxHeaderText)}}
+		}
+	}
+	return messages
+}
+
+// post отправляет сообщение, один раз повторяя его после 429 с Retry-After
+func (s *Slack) post(ctx context.Context, msg slackMessage) error {
+	_, err := postJSON(ctx, s.client, "slack webhook", s.Webhook, msg, nil)
+	var se *StatusError
+	if errors.As(err, &se) && se.StatusCode == http.StatusTooManyRequests {
+		wait := se.RetryAfter
+		if wait == 0 {
+			wait = time.Second
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+		_, err = postJSON(ctx, s.client, "slack webhook", s.Webhook, msg, nil)
+	}
+	return err
+}
+
+// slackEscape экранирует управляющие символы mrkdwn
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// slackLink оформляет ссылку как <url|текст>; символ | в тексте ломает разметку
+func slackLink(u string) string {
+	if u == "" {
+		return ""
+	}
+	return "<" + slackEscape(u) + "|" + strings.ReplaceAll(slackEscape(u), "|", "¦") + ">"
+}
@@ -0,0 +1,246 @@
+package agent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Splunk отправляет события в HTTP Event Collector: пачками по BatchSize событий в одном запросе
+// к /services/collector/event, с авторизацией "Splunk <token>" и опциональным gzip.
+// При UseAck запрос идёт в канал X-Splunk-Request-Channel, и пачка считается доставленной
+// только после подтверждения индексатором через /services/collector/ack
+type Splunk struct {
+	URL        string // базовый адрес HEC, например https://splunk:8088
+	Token      string
+	Index      string
+	Source     string
+	SourceType string
+	BatchSize  int
+	Gzip       bool
+	UseAck     bool
+	AckTimeout time.Duration
+
+	host    string
+	channel string
+	client  *http.Client
+}
+
+const (
+	splunkDefaultSource     = "/go_web_parser_agent"
+	splunkDefaultSourceType = "go_web_parser_agent"
+	splunkAckPollInterval   = time.Second
+)
+
+func NewSplunk(url, token string) *Splunk {
+	hostname, _ := os.Hostname()
+	return &Splunk{
+		URL:        strings.TrimRight(url, "/"),
+		Token:      token,
+		Source:     splunkDefaultSource,
+		SourceType: splunkDefaultSourceType,
+		BatchSize:  500,
+		AckTimeout: 2 * time.Minute,
+		host:       hostname,
+		channel:    newChannelID(),
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *Splunk) Name() string {
+	return "splunk"
+}
+
+// splunkEvent — формат события HEC; time в секундах с дробной частью
+type splunkEvent struct {
+	Time       float64  `json:"time"`
+	Host       string   `json:"host,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	SourceType string   `json:"sourcetype,omitempty"`
+	Index      string   `json:"index,omitempty"`
+	Event      envelope `json:"event"`
+}
+
+type splunkResponse struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	AckID *int64 `json:"ackId"`
+}
+
+func (s *Splunk) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	hec := make([]splunkEvent, 0, len(events))
+	for _, d := range events {
+		hec = append(hec, s.event(d.CreatedAt, domainEnvelope(d)))
+	}
+	return s.send(ctx, hec)
+}
+
+func (s *Splunk) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	hec := make([]splunkEvent, 0, len(events))
+	for _, l := range events {
+		hec = append(hec, s.event(l.CreatedAt, linkEnvelope(l)))
+	}
+	return s.send(ctx, hec)
+}
+
+func (s *Splunk) event(t time.Time, env envelope) splunkEvent {
+	return splunkEvent{
+		Time:       float64(t.UnixMilli()) / 1000,
+		Host:       s.host,
+		Source:     s.Source,
+		SourceType: s.SourceType,
+		Index:      s.Index,
+		Event:      env,
+	}
+}
+
+func (s *Splunk) send(ctx context.Context, events []splunkEvent) error {
+	size := s.BatchSize
+	if size <= 0 {
+		size = len(events)
+	}
+
+	var acks []int64
+	for start := 0; start < len(events); start += size {
+		end := min(start+size, len(events))
+		ackID, err := s.post(ctx, events[start:end])
+		if err != nil {
+			return fmt.Errorf("splunk batch %d-%d: %w", start, end, err)
+		}
+		if ackID != nil {
+			acks = append(acks, *ackID)
+		}
+	}
+
+	if s.UseAck && len(acks) > 0 {
+		return s.waitAcks(ctx, acks)
+	}
+	return nil
+}
+
+// post отправляет пачку: события HEC идут подряд без разделителя-массива
+func (s *Splunk) post(ctx context.Context, events []splunkEvent) (*int64, error) {
+	var body bytes.Buffer
+	var w io.Writer = &body
+	var gz *gzip.Writer
+	if s.Gzip {
+		gz = gzip.NewWriter(&body)
+		w = gz
+	}
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return nil, err
+		}
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL+"/services/collector/event", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	s.authorize(req)
+
+	data, err := doRequest(s.client, "splunk", req)
+	if err != nil {
+		return nil, err
+	}
+	var resp splunkResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("decode hec response: %w", err)
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("hec code %d: %s", resp.Code, resp.Text)
+	}
+	if s.UseAck && resp.AckID == nil {
+		return nil, errors.New("hec returned no ackId, is indexer acknowledgement enabled for the token?")
+	}
+	return resp.AckID, nil
+}
+
+// waitAcks опрашивает /services/collector/ack, пока индексатор не подтвердит все пачки или не истечёт AckTimeout
+func (s *Splunk) waitAcks(ctx context.Context, acks []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.AckTimeout)
+	defer cancel()
+
+	pending := acks
+	for {
+		var err error
+		pending, err = s.queryAcks(ctx, pending)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, splunkAckPollInterval); err != nil {
+			return fmt.Errorf("splunk: %d batches not acknowledged: %w", len(pending), err)
+		}
+	}
+}
+
+func (s *Splunk) queryAcks(ctx context.Context, acks []int64) ([]int64, error) {
+	data, err := json.Marshal(map[string][]int64{"acks": acks})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL+"/services/collector/ack", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.authorize(req)
+
+	body, err := doRequest(s.client, "splunk", req)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Acks map[string]bool `json:"acks"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decode hec ack response: %w", err)
+	}
+
+	var pending []int64
+	for _, id := range acks {
+		if !resp.Acks[strconv.FormatInt(id, 10)] {
+			pending = append(pending, id)
+		}
+	}
+	return pending, nil
+}
+
+func (s *Splunk) authorize(req *http.Request) {
+	req.Header.Set("Authorization", "Splunk "+s.Token)
+	if s.UseAck {
+		req.Header.Set("X-Splunk-Request-Channel", s.channel)
+	}
+}
+
+// newChannelID генерирует случайный UUID v4 для канала HEC
+func newChannelID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
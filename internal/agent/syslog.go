@@ -0,0 +1,314 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// форматы тела syslog-сообщения
+const (
+	SyslogCEF  = "cef"
+	SyslogLEEF = "leef"
+)
+
+const (
+	syslogFacilityLocal0 = 16
+	syslogSeverityNotice = 5
+	syslogAppName        = "go_web_parser_agent"
+
+	siemVendor  = "zeshi09"
+	siemProduct = "go_web_parser_agent"
+	siemVersion = "1.0"
+	cefSeverity = 3
+)
+
+// Syslog шлёт по сообщению RFC 5424 на каждый домен и ссылку, тело — CEF или LEEF для SIEM.
+// По TCP и TLS сообщения разделяются octet counting (RFC 6587 / RFC 5425), по UDP — по одному в датаграмме.
+// Соединение держится открытым и переоткрывается после ошибки записи
+type Syslog struct {
+	Network   string // udp, tcp или tls
+	Addr      string
+	Format    string
+	TLSConfig *tls.Config
+
+	hostname string
+	mu       sync.Mutex
+	conn     net.Conn
+}
+
+func NewSyslog(network, addr, format string) *Syslog {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &Syslog{
+		Network:  network,
+		Addr:     addr,
+		Format:   format,
+		hostname: hostname,
+	}
+}
+
+func (s *Syslog) Name() string {
+	return "syslog"
+}
+
+func (s *Syslog) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// siemEvent — событие в нейтральном виде, из которого собираются CEF и LEEF
+type siemEvent struct {
+	signature string
+	name      string
+	severity  int
+	at        time.Time
+	cef       [][2]string
+	leef      [][2]string
+}
+
+func (s *Syslog) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	msgs := make([]siemEvent, 0, len(events))
+	for _, d := range events {
+		msgs = append(msgs, siemEvent{
+			signature: EventDomainDiscovered,
+			name:      "New landing domain discovered",
+			severity:  cefSeverityOf(d.Severity),
+			at:        d.CreatedAt,
+			cef: [][2]string{
+				{"rt", strconv.FormatInt(d.CreatedAt.UnixMilli(), 10)},
+				{"dhost", d.LandingDomain},
+				{"externalId", strconv.Itoa(d.ID)},
+				{"cs1Label", "eventId"},
+				{"cs1", d.EventID()},
+			},
+			leef: [][2]string{
+				{"devTime", strconv.FormatInt(d.CreatedAt.UnixMilli(), 10)},
+				{"devTimeFormat", "epoch"},
+				{"dhost", d.LandingDomain},
+				{"externalId", strconv.Itoa(d.ID)},
+				{"eventId", d.EventID()},
+				{"sev", strconv.Itoa(cefSeverityOf(d.Severity))},
+				{"tags", strings.Join(d.Tags, ",")},
+			},
+		})
+		msgs[len(msgs)-1].withTags(d.Tags)
+	}
+	return s.send(ctx, msgs)
+}
+
+func (s *Syslog) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	msgs := make([]siemEvent, 0, len(events))
+	for _, l := range events {
+		host := ""
+		if u, err := url.Parse(l.URL); err == nil {
+			host = u.Hostname()
+		}
+		msgs = append(msgs, siemEvent{
+			signature: EventSocialLinkDiscovered,
+			name:      "New social link discovered",
+			severity:  cefSeverityOf(l.Severity),
+			at:        l.CreatedAt,
+			cef: [][2]string{
+				{"rt", strconv.FormatInt(l.CreatedAt.UnixMilli(), 10)},
+				{"request", l.URL},
+				{"dhost", host},
+				{"externalId", strconv.Itoa(l.ID)},
+				{"cs1Label", "eventId"},
+				{"cs1", l.EventID()},
+				{"cs2Label", "pageUrl"},
+				{"cs2", l.PageURL},
+				{"cs3Label", "platform"},
+				{"cs3", l.PlatformName()},
+			},
+			leef: [][2]string{
+				{"devTime", strconv.FormatInt(l.CreatedAt.UnixMilli(), 10)},
+				{"devTimeFormat", "epoch"},
+				{"url", l.URL},
+				{"dhost", host},
+				{"externalId", strconv.Itoa(l.ID)},
+				{"eventId", l.EventID()},
+				{"pageUrl", l.PageURL},
+				{"platform", l.PlatformName()},
+				{"sev", strconv.Itoa(cefSeverityOf(l.Severity))},
+				{"tags", strings.Join(l.Tags, ",")},
+			},
+		})
+		msgs[len(msgs)-1].withTags(l.Tags)
+	}
+	return s.send(ctx, msgs)
+}
+
+// withTags добавляет теги из фильтров в CEF-расширение cs4; в LEEF они уже лежат в поле tags
+func (e *siemEvent) withTags(tags []string) {
+	if len(tags) > 0 {
+		e.cef = append(e.cef, [2]string{"cs4Label", "tags"}, [2]string{"cs4", strings.Join(tags, ",")})
+	}
+}
+
+func (s *Syslog) send(ctx context.Context, events []siemEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range events {
+		var body string
+		if s.Format == SyslogLEEF {
+			body = formatLEEF(e)
+		} else {
+			body = formatCEF(e)
+		}
+		msg := s.rfc5424(e.signature, e.at, body)
+
+		// одна повторная попытка на новом соединении: tcp-сессию мог закрыть коллектор
+		if err := s.write(ctx, msg); err != nil {
+			s.dropConn()
+			if err := s.write(ctx, msg); err != nil {
+				s.dropConn()
+				return fmt.Errorf("syslog %s %s: %w", s.Network, s.Addr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// rfc5424 собирает заголовок: <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+func (s *Syslog) rfc5424(msgID string, at time.Time, body string) string {
+	pri := syslogFacilityLocal0*8 + syslogSeverityNotice
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - %s",
+		pri, at.UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName, os.Getpid(), msgID, body)
+}
+
+func (s *Syslog) write(ctx context.Context, msg string) error {
+	if s.conn == nil {
+		if err := s.dial(ctx); err != nil {
+			return err
+		}
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+
+	frame := msg
+	if s.Network != "udp" {
+		frame = strconv.Itoa(len(msg)) + " " + msg
+	}
+	_, err := s.conn.Write([]byte(frame))
+	return err
+}
+
+func (s *Syslog) dial(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var err error
+	switch s.Network {
+	case "tls":
+		cfg := s.TLSConfig
+		if cfg == nil {
+			host, _, _ := net.SplitHostPort(s.Addr)
+			cfg = &tls.Config{ServerName: host}
+		}
+		s.conn, err = (&tls.Dialer{NetDialer: dialer, Config: cfg}).DialContext(ctx, "tcp", s.Addr)
+	case "udp", "tcp":
+		s.conn, err = dialer.DialContext(ctx, s.Network, s.Addr)
+	default:
+		return fmt.Errorf("unknown syslog network %q", s.Network)
+	}
+	return err
+}
+
+func (s *Syslog) dropConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// cefSeverityOf переводит severity из фильтров в шкалу CEF 0-10; число передаётся как есть
+func cefSeverityOf(severity string) int {
+	switch strings.ToLower(severity) {
+	case "":
+		return cefSeverity
+	case "low":
+		return 3
+	case "medium":
+		return 5
+	case "high":
+		return 8
+	case "critical":
+		return 10
+	}
+	if n, err := strconv.Atoi(severity); err == nil && n >= 0 && n <= 10 {
+		return n
+	}
+	return cefSeverity
+}
+
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func formatCEF(e siemEvent) string {
+	var b strings.Builder
+	b.WriteString("CEF:0|")
+	for _, f := range []string{siemVendor, siemProduct, siemVersion, e.signature, e.name} {
+		b.WriteString(cefHeaderEscape(f))
+		b.WriteString("|")
+	}
+	b.WriteString(strconv.Itoa(e.severity))
+	b.WriteString("|")
+	first := true
+	for _, kv := range e.cef {
+		if kv[1] == "" {
+			continue
+		}
+		if !first {
+			b.WriteString(" ")
+		}
+		first = false
+		b.WriteString(kv[0])
+		b.WriteString("=")
+		b.WriteString(cefExtEscape(kv[1]))
+	}
+	return b.String()
+}
+
+// LEEF:1.0|Vendor|Product|Version|EventID|key=value<TAB>key=value
+func formatLEEF(e siemEvent) string {
+	var b strings.Builder
+	b.WriteString("LEEF:1.0|")
+	for _, f := range []string{siemVendor, siemProduct, siemVersion, e.signature} {
+		b.WriteString(strings.ReplaceAll(f, "|", "_"))
+		b.WriteString("|")
+	}
+	first := true
+	for _, kv := range e.leef {
+		if kv[1] == "" {
+			continue
+		}
+		if !first {
+			b.WriteString("\t")
+		}
+		first = false
+		b.WriteString(kv[0])
+		b.WriteString("=")
+		b.WriteString(strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(kv[1]))
+	}
+	return b.String()
+}
+
+func cefHeaderEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ").Replace(s)
+}
+
+func cefExtEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
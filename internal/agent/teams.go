@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// сколько элементов выводить в одной карточке по умолчанию, остальное сворачивается в "и ещё N"
+const teamsDefaultMaxItems = 20
+
+// Teams шлёт уведомления во входящий вебхук Teams (или триггер Workflows) в виде Adaptive Card:
+// заголовок и по FactSet на каждый элемент пачки; заголовок и названия полей — из подписей Templates
+type Teams struct {
+	Webhook   string
+	MaxItems  int
+	Templates *Templates
+	client    *http.Client
+}
+
+func NewTeams(webhook string) *Teams {
+	return &Teams{
+		Webhook:   webhook,
+		MaxItems:  teamsDefaultMaxItems,
+		Templates: DefaultTemplates(),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (t *Teams) label(key string) string {
+	return t.Templates.Label(t.Name(), key, 0)
+}
+
+func (t *Teams) Name() string {
+	return "teams"
+}
+
+type teamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type teamsElement struct {
+	Type      string      `json:"type"`
+	Text      string      `json:"text,omitempty"`
+	Size      string      `json:"size,omitempty"`
+	Weight    string      `json:"weight,omitempty"`
+	Wrap      bool        `json:"wrap,omitempty"`
+	IsSubtle  bool        `json:"isSubtle,omitempty"`
+	Separator bool        `json:"separator,omitempty"`
+	Facts     []teamsFact `json:"facts,omitempty"`
+}
+
+type teamsCard struct {
+	Schema  string         `json:"$schema"`
+	Type    string         `json:"type"`
+	Version string         `json:"version"`
+	Body    []teamsElement `json:"body"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	ContentURL  *string   `json:"contentUrl"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+func (t *Teams) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	domain, found := t.label("domain"), t.label("domain.found")
+	items := make([][]teamsFact, 0, len(events))
+	for _, d := range events {
+		items = append(items, []teamsFact{
+			{Title: domain, Value: d.LandingDomain},
+			{Title: found, Value: d.CreatedAt.Format(time.RFC3339)},
+		})
+	}
+	return t.post(ctx, t.Templates.Label(t.Name(), "domains.title", len(events)), items)
+}
+
+func (t *Teams) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	link, platform, page, found := t.label("link"), t.label("platform"), t.label("page"), t.label("link.found")
+	items := make([][]teamsFact, 0, len(events))
+	for _, l := range events {
+		facts := []teamsFact{{Title: link, Value: teamsLink(l.URL)}}
+		if p := l.PlatformName(); p != "" {
+			facts = append(facts, teamsFact{Title: platform, Value: p})
+		}
+		facts = append(facts,
+			teamsFact{Title: page, Value: teamsLink(l.PageURL)},
+			teamsFact{Title: found, Value: l.CreatedAt.Format(time.RFC3339)},
+		)
+		items = append(items, facts)
+	}
+	return t.post(ctx, t.Templates.Label(t.Name(), "links.title", len(events)), items)
+}
+
+func (t *Teams) post(ctx context.Context, title string, items [][]teamsFact) error {
+	body := []teamsElement{{Type: "TextBlock", Text: title, Size: "Large", Weight: "Bolder", Wrap: true}}
+
+	shown := items
+	if t.MaxItems > 0 && len(items) > t.MaxItems {
+		shown = items[:t.MaxItems]
+	}
+	for i, facts := range shown {
+		body = append(body, teamsElement{Type: "FactSet", Facts: facts, Separator: i > 0})
+	}
+	if rest := len(items) - len(shown); rest > 0 {
+		body = append(body, teamsElement{
+			Type:      "TextBlock",
+			Text:      t.Templates.Label(t.Name(), "more", rest),
+			IsSubtle:  true,
+			Wrap:      true,
+			Separator: true,
+		})
+	}
+
+	msg := teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+			},
+		}},
+	}
+	_, err := postJSON(ctx, t.client, "teams webhook", t.Webhook, msg, nil)
+	return err
+}
+
+// teamsLink оформляет ссылку в markdown-подмножестве Adaptive Cards
+func teamsLink(u string) string {
+	if u == "" {
+		return ""
+	}
+	return "[" + u + "](" + u + ")"
+}
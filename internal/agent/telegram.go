@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	telegramAPI        = "https://api.telegram.org"
+	telegramMaxMessage = 4096
+	telegramMaxRetries = 3
+)
+
+// Telegram шлёт уведомления в чат через Bot API (sendMessage с MarkdownV2): строка на событие
+// из шаблонов telegram/<kind>.item, заголовок — из подписей Templates.
+// APIURL можно подменить на локальный фейковый сервер
+type Telegram struct {
+	APIURL    string
+	Token     string
+	ChatID    string
+	Templates *Templates
+	client    *http.Client
+}
+
+func NewTelegram(token, chatID string) *Telegram {
+	return &Telegram{
+		APIURL:    telegramAPI,
+		Token:     token,
+		ChatID:    chatID,
+		Templates: DefaultTemplates(),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (t *Telegram) Name() string {
+	return "telegram"
+}
+
+// Retarget возвращает копию, которая пишет в другой чат (channel — chat_id или @username)
+func (t *Telegram) Retarget(rt RouteTarget) (Notifier, error) {
+	if rt.Format != "" {
+		return nil, fmt.Errorf("telegram does not support format %q", rt.Format)
+	}
+	c := *t
+	if rt.Channel != "" {
+		c.ChatID = rt.Channel
+	}
+	return &c, nil
+}
+
+func (t *Telegram) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	lines := make([]string, 0, len(events))
+	for _, d := range events {
+		line, err := t.Templates.Render(t.Name(), TemplateDomains, TemplateItem, TemplateData{Domain: d, Count: 1})
+		if err != nil {
+			return err
+		}
+		lines = append(lines, tgFit(line, "• "+d.LandingDomain))
+	}
+	return t.send(ctx, "*"+tgEscape(t.Templates.Label(t.Name(), "domains.title", len(events)))+"*", lines)
+}
+
+func (t *Telegram) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	lines := make([]string, 0, len(events))
+	for _, l := range events {
+		line, err := t.Templates.Render(t.Name(), TemplateLinks, TemplateItem, TemplateData{Link: l, Count: 1})
+		if err != nil {
+			return err
+		}
+		plain := "• " + l.URL
+		if p := l.PlatformName(); p != "" {
+			plain += " (" + p + ")"
+		}
+		lines = append(lines, tgFit(line, plain+"\n   ↳ "+l.PageURL))
+	}
+	return t.send(ctx, "*"+tgEscape(t.Templates.Label(t.Name(), "links.title", len(events)))+"*", lines)
+}
+
+// tgFit возвращает разметку строки, если она влезает в одно сообщение, а иначе — обрезанный текст
+// без ссылки: резать готовую MarkdownV2 нельзя, можно разорвать экранирование или [..](..).
+// Экранирование не более чем удваивает длину, поэтому исходник режется до половины лимита
+func tgFit(markup, plain string) string {
+	if utf8.RuneCountInString(markup) <= telegramMaxMessage {
+		return markup
+	}
+	return tgEscape(truncateText(plain, telegramMaxMessage/2))
+}
+
+// send склеивает строки в сообщения не длиннее 4096 символов; каждая строка уже не длиннее лимита (tgFit)
+func (t *Telegram) send(ctx context.Context, title string, lines []string) error {
+	var messages []string
+	var b strings.Builder
+	b.WriteString(title)
+	for _, line := range lines {
+		if utf8.RuneCountInString(b.String())+1+utf8.RuneCountInString(line) > telegramMaxMessage {
+			messages = append(messages, b.String())
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+	}
+	if b.Len() > 0 {
+		messages = append(messages, b.String())
+	}
+
+	for i, text := range messages {
+		if err := t.sendMessage(ctx, text); err != nil {
+			return fmt.Errorf("telegram message %d/%d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// sendMessage повторяет запрос после 429, выжидая retry_after из ответа
+func (t *Telegram) sendMessage(ctx context.Context, text string) error {
+	payload := map[string]any{
+		"chat_id":                  t.ChatID,
+		"text":                     text,
+		"parse_mode":               "MarkdownV2",
+		"disable_web_page_preview": true,
+	}
+	url := strings.TrimRight(t.APIURL, "/") + "/bot" + t.Token + "/sendMessage"
+
+	for attempt := 0; ; attempt++ {
+		_, err := postJSON(ctx, t.client, "telegram", url, payload, nil)
+		var se *StatusError
+		if !errors.As(err, &se) || se.StatusCode != http.StatusTooManyRequests || attempt == telegramMaxRetries {
+			return tgRedact(err, t.Token)
+		}
+
+		var resp telegramResponse
+		wait := time.Second
+		if json.Unmarshal([]byte(se.Body), &resp) == nil && resp.Parameters.RetryAfter > 0 {
+			wait = time.Duration(resp.Parameters.RetryAfter) * time.Second
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// tgRedact убирает токен бота из текста ошибки (он входит в URL запроса)
+func tgRedact(err error, token string) error {
+	if err == nil || token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), token, "<token>"))
+}
+
+// tgEscape экранирует спецсимволы MarkdownV2
+func tgEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!\\", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// tgLink оформляет ссылку; внутри (...) MarkdownV2 требует экранировать только ) и \
+func tgLink(u string) string {
+	target := strings.NewReplacer("\\", "\\\\", ")", "\\)").Replace(u)
+	return "[" + tgEscape(u) + "](" + target + ")"
+}
@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// fakeTelegram — Bot API, который запоминает тексты sendMessage и первые replies429 раз отвечает 429
+type fakeTelegram struct {
+	mu         sync.Mutex
+	texts      []string
+	requests   int
+	replies429 int
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	if r.URL.Path != "/bot123:secret/sendMessage" {
+		http.NotFound(w, r)
+		return
+	}
+	if f.replies429 > 0 {
+		f.replies429--
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":1}}`))
+		return
+	}
+	var body struct {
+		ChatID    string `json:"chat_id"`
+		Text      string `json:"text"`
+		ParseMode string `json:"parse_mode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ParseMode != "MarkdownV2" || body.ChatID != "42" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.texts = append(f.texts, body.Text)
+	w.Write([]byte(`{"ok":true}`))
+}
+
+func newTestTelegram(t *testing.T, f *fakeTelegram) *Telegram {
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	tg := NewTelegram("123:secret", "42")
+	tg.APIURL = srv.URL
+	return tg
+}
+
+func TestTelegramEscapesMarkdownV2(t *testing.T) {
+	f := &fakeTelegram{}
+	tg := newTestTelegram(t, f)
+
+	err := tg.NotifyLinks(context.Background(), []LinkEvent{{
+		ID:      1,
+		URL:     "https://vk.com/a_b(c)",
+		PageURL: "https://evil-site.com/page!",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.texts) != 1 {
+		t.Fatalf("got %d messages, want 1", len(f.texts))
+	}
+	want := "*Появились новые ссылки: 1*\n" +
+		`• [https://vk\.com/a\_b\(c\)](https://vk.com/a_b(c\)) \(vk\.com\)` + "\n" +
+		`   ↳ https://evil\-site\.com/page\!`
+	if f.texts[0] != want {
+		t.Fatalf("text:\n%s\nwant:\n%s", f.texts[0], want)
+	}
+}
+
+func TestTelegramSplitsLongBatches(t *testing.T) {
+	f := &fakeTelegram{}
+	tg := newTestTelegram(t, f)
+
+	events := make([]DomainEvent, 0, 500)
+	for i := 0; i < 500; i++ {
+		events = append(events, DomainEvent{ID: i, LandingDomain: strings.Repeat("a", 20) + ".example.com"})
+	}
+	// строка длиннее лимита сама по себе должна быть обрезана, а не уйти отдельным слишком длинным сообщением
+	events = append(events, DomainEvent{ID: 500, LandingDomain: strings.Repeat("b.", 5000) + "com"})
+
+	if err := tg.NotifyDomains(context.Background(), events); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.texts) < 2 {
+		t.Fatalf("got %d messages, want the batch split", len(f.texts))
+	}
+	lines := 0
+	for i, text := range f.texts {
+		if n := utf8.RuneCountInString(text); n > telegramMaxMessage {
+			t.Errorf("message %d has %d runes", i, n)
+		}
+		lines += strings.Count(text, "• ")
+	}
+	if lines != len(events) {
+		t.Errorf("got %d lines, want %d", lines, len(events))
+	}
+	last := f.texts[len(f.texts)-1]
+	if !strings.HasSuffix(last, "…") || strings.HasSuffix(last, "\\…") {
+		t.Errorf("long line not truncated cleanly: ...%s", last[max(0, len(last)-20):])
+	}
+}
+
+func TestTelegramRetriesAfter429(t *testing.T) {
+	f := &fakeTelegram{replies429: 1}
+	tg := newTestTelegram(t, f)
+
+	if err := tg.NotifyDomains(context.Background(), []DomainEvent{{ID: 1, LandingDomain: "example.com"}}); err != nil {
+		t.Fatal(err)
+	}
+	if f.requests != 2 || len(f.texts) != 1 {
+		t.Fatalf("requests=%d messages=%d, want 2 and 1", f.requests, len(f.texts))
+	}
+}
+
+func TestTelegramRedactsToken(t *testing.T) {
+	tg := newTestTelegram(t, &fakeTelegram{})
+
+	// запрос не уходит, а ошибка клиента содержит URL вместе с токеном
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := tg.NotifyDomains(ctx, []DomainEvent{{ID: 1, LandingDomain: "example.com"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("bot token leaked into error: %v", err)
+	}
+}
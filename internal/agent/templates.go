@@ -0,0 +1,280 @@
+package agent
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/rs/zerolog/log"
+)
+
+// встроенные шаблоны сообщений: templates/<locale>/[<sink>/]<kind>[.<format>].tmpl
+//
+//go:embed templates
+var builtinTemplates embed.FS
+
+// локали встроенных шаблонов
+const (
+	LocaleRU = "ru"
+	LocaleEN = "en"
+)
+
+// имена шаблонов по типу сущности
+const (
+	TemplateDomains = "domains"
+	TemplateLinks   = "links"
+)
+
+// форматы и виды шаблонов sink'ов, кроме mattermost
+const (
+	TemplateItem   = "item"   // одна строка сообщения telegram и slack на событие: domains.item, links.item
+	TemplateDigest = "digest" // текстовая часть письма email: digest.text
+)
+
+// TemplateLabels — шаблон с подписями ({{define "<ключ>"}}...{{end}}) для sink'ов, которые собирают
+// сообщение сами: slack, telegram, teams, discord, email. Файл labels.tmpl из TEMPLATES_DIR
+// не заменяет встроенные подписи целиком, а перекрывает только определённые в нём ключи
+const TemplateLabels = "labels"
+
+// Templates — шаблоны текстовых сообщений (text/template) для sink'ов.
+// Шаблон ищется по имени <kind>[.<format>] (domains, links.table) в таком порядке:
+// <dir>/<sink>/<name>.tmpl, <dir>/<name>.tmpl, встроенный шаблон выбранной локали.
+//
+// Какие шаблоны использует sink:
+//
+//	mattermost  domains, links и их форматы (compact, table, summary, свои из TEMPLATES_DIR)
+//	telegram    telegram/domains.item, telegram/links.item — строка MarkdownV2 на событие
+//	slack       slack/domains.item, slack/links.item — строка mrkdwn на событие
+//	email       email/digest.text — текстовая часть письма; HTML-часть собирается из подписей
+//
+// Строки item нарезаются на сообщения самим sink'ом с учётом лимитов. Заголовки, тема письма и
+// названия полей карточек teams и discord берутся из шаблона labels той же локали, см. Label.
+//
+// Данные шаблона — TemplateData; доступные функции:
+//
+//	defang       "https://evil.com" → "hxxps://evil[.]com"
+//	truncate     {{truncate 80 .URL}} обрезает до 80 символов с "…"
+//	host         хост URL без "www."
+//	platformIcon эмодзи платформы: {{platformIcon .PlatformName}}
+//	cell         экранирует "|" для markdown-таблиц
+//	join         {{join .Tags ", "}}
+//	tgEscape, tgLink        экранирование и ссылка MarkdownV2 для telegram
+//	slackEscape, slackLink  экранирование и ссылка <url|текст> для slack
+type Templates struct {
+	Locale string
+	byKey  map[string]*template.Template // "mattermost/domains.table" или "domains"
+}
+
+// TemplateData — данные, с которыми исполняется шаблон; в шаблонах item заполнено только Domain или Link
+type TemplateData struct {
+	Domains []DomainEvent
+	Links   []LinkEvent
+	Count   int
+
+	Domain DomainEvent
+	Link   LinkEvent
+}
+
+var templateFuncs = template.FuncMap{
+	"defang":       defang,
+	"truncate":     func(n int, s string) string { return truncateText(s, n) },
+	"host":         hostOf,
+	"platformIcon": platformIcon,
+	"cell":         func(s string) string { return strings.ReplaceAll(s, "|", `\|`) },
+	"join":         strings.Join,
+	"tgEscape":     tgEscape,
+	"tgLink":       tgLink,
+	"slackEscape":  slackEscape,
+	"slackLink":    slackLink,
+}
+
+// LoadTemplates загружает встроенные шаблоны локали и перекрывает их файлами *.tmpl из dir (может быть пустым).
+// Все шаблоны разбираются сразу, поэтому синтаксические ошибки видны при старте
+func LoadTemplates(dir, locale string) (*Templates, error) {
+	if locale == "" {
+		locale = LocaleRU
+	}
+	t := &Templates{Locale: locale, byKey: make(map[string]*template.Template)}
+
+	builtin, err := fs.Sub(builtinTemplates, "templates/"+locale)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, pattern := range []string{"*.tmpl", "*/*.tmpl"} {
+		matched, err := fs.Glob(builtin, pattern)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, matched...)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("unknown locale %q, built-in locales: %s, %s", locale, LocaleRU, LocaleEN)
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(builtin, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.add(strings.TrimSuffix(name, ".tmpl"), string(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir == "" {
+		return t, nil
+	}
+	// файлы в корне dir — общие для всех sink'ов, в подкаталогах — для sink'а с таким именем
+	for _, pattern := range []string{"*.tmpl", "*/*.tmpl"} {
+		files, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			rel, err := filepath.Rel(dir, file)
+			if err != nil {
+				return nil, err
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if err := t.add(strings.TrimSuffix(filepath.ToSlash(rel), ".tmpl"), string(data)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return t, nil
+}
+
+// DefaultTemplates — встроенные русские шаблоны, используются, если sink'у не задали другие
+func DefaultTemplates() *Templates {
+	t, err := LoadTemplates("", LocaleRU)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (t *Templates) add(key, text string) error {
+	tmpl := template.New(key).Funcs(templateFuncs).Option("missingkey=error")
+	// подписи доопределяются поверх общих: <sink>/labels поверх labels, labels из каталога — поверх встроенных
+	if path.Base(key) == TemplateLabels {
+		if base, ok := t.byKey[TemplateLabels]; ok {
+			var err error
+			if tmpl, err = base.Clone(); err != nil {
+				return fmt.Errorf("template %s: %w", key, err)
+			}
+		}
+	}
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", key, err)
+	}
+	t.byKey[key] = tmpl
+	return nil
+}
+
+func templateName(kind, format string) string {
+	if format == "" || format == MMFormatList {
+		return kind
+	}
+	return kind + "." + format
+}
+
+func (t *Templates) lookup(sink, name string) *template.Template {
+	if tmpl, ok := t.byKey[path.Join(sink, name)]; ok {
+		return tmpl
+	}
+	return t.byKey[name]
+}
+
+// Has сообщает, есть ли для sink'а шаблон вида kind с форматом format
+func (t *Templates) Has(sink, kind, format string) bool {
+	return t.lookup(sink, templateName(kind, format)) != nil
+}
+
+// Render исполняет шаблон kind/format для sink'а; завершающие переводы строк отрезаются
+func (t *Templates) Render(sink, kind, format string, data TemplateData) (string, error) {
+	name := templateName(kind, format)
+	tmpl := t.lookup(sink, name)
+	if tmpl == nil {
+		return "", fmt.Errorf("no template %q for %s", name, sink)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// Label исполняет подпись key из шаблона labels sink'а с Count = count.
+// Неизвестная подпись или ошибка шаблона не должны терять уведомление, поэтому тогда возвращается сам key
+func (t *Templates) Label(sink, key string, count int) string {
+	var sub *template.Template
+	if tmpl := t.lookup(sink, TemplateLabels); tmpl != nil {
+		sub = tmpl.Lookup(key)
+	}
+	if sub == nil {
+		log.Warn().Str("sink", sink).Str("label", key).Msg("no such label in templates")
+		return key
+	}
+	var b strings.Builder
+	if err := sub.Execute(&b, TemplateData{Count: count}); err != nil {
+		log.Warn().Err(err).Str("sink", sink).Str("label", key).Msg("label template failed")
+		return key
+	}
+	return b.String()
+}
+
+// defang обезвреживает адрес, чтобы он не стал кликабельной ссылкой: схема http → hxxp, точки → [.]
+func defang(s string) string {
+	if rest, ok := strings.CutPrefix(s, "http"); ok {
+		s = "hxxp" + rest
+	}
+	return strings.ReplaceAll(s, ".", "[.]")
+}
+
+// truncateText обрезает строку до n символов, заменяя хвост на "…"
+func truncateText(s string, n int) string {
+	r := []rune(s)
+	if n <= 0 || len(r) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(r[:n-1]) + "…"
+}
+
+var platformIcons = map[string]string{
+	"t.me":          "✈️",
+	"telegram.me":   "✈️",
+	"vk.com":        "🟦",
+	"ok.ru":         "🟧",
+	"youtube.com":   "▶️",
+	"youtu.be":      "▶️",
+	"instagram.com": "📷",
+	"facebook.com":  "📘",
+	"twitter.com":   "🐦",
+	"x.com":         "🐦",
+	"tiktok.com":    "🎵",
+	"linkedin.com":  "💼",
+	"github.com":    "🐙",
+	"wa.me":         "💬",
+	"whatsapp.com":  "💬",
+}
+
+// platformIcon возвращает эмодзи платформы, для неизвестных — 🔗
+func platformIcon(platform string) string {
+	platform = strings.TrimPrefix(strings.ToLower(platform), "www.")
+	if icon, ok := platformIcons[platform]; ok {
+		return icon
+	}
+	return "🔗"
+}
@@ -0,0 +1,219 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook — универсальный sink для машинных потребителей: POST структурированного JSON с подписью HMAC-SHA256.
+//
+// Схема тела, версия 1 (меняется только с повышением schema_version):
+//
+//	{
+//	  "schema_version": 1,
+//	  "type": "domain.discovered" | "sociallink.discovered",
+//	  "sent_at": "2025-09-10T09:45:18Z",
+//	  "events": [
+//	    {
+//	      "id": "domain:115",                       // стабилен между повторами, годится для дедупликации
+//	      "cursor": {"created_at": "...", "id": 115}, // позиция строки в keyset-курсоре агента
+//	      "data": {"id": 115, "landing_domain": "example.com", "created_at": "..."}
+//	    }
+//	  ]
+//	}
+//
+// Для sociallink.discovered data = {"id", "url", "page_url", "domain", "created_at"}.
+// Если событие прошло через фильтры, в data добавляются "tags" и "severity".
+//
+// Заголовки:
+//
+//	X-Watcher-Schema-Version: 1
+//	X-Watcher-Delivery:       sha256 от id событий пачки, одинаков при повторной отправке
+//	X-Watcher-Timestamp:      unix-время отправки в секундах
+//	X-Watcher-Signature:      sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+//
+// Получатель проверяет подпись и отбрасывает запросы со слишком старым timestamp, см. VerifyWebhookSignature
+type Webhook struct {
+	URL    string
+	Secret string
+	client *http.Client
+}
+
+const WebhookSchemaVersion = 1
+
+// типы событий, общие для машинных sink'ов
+const (
+	EventDomainDiscovered     = "domain.discovered"
+	EventSocialLinkDiscovered = "sociallink.discovered"
+)
+
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{
+		URL:    url,
+		Secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+type webhookCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
+
+type webhookEvent struct {
+	ID     string        `json:"id"`
+	Cursor webhookCursor `json:"cursor"`
+	Data   any           `json:"data"`
+}
+
+type webhookPayload struct {
+	SchemaVersion int            `json:"schema_version"`
+	Type          string         `json:"type"`
+	SentAt        time.Time      `json:"sent_at"`
+	Events        []webhookEvent `json:"events"`
+}
+
+// данные событий в том же виде, что и колонки таблиц парсера
+type domainData struct {
+	ID            int       `json:"id"`
+	LandingDomain string    `json:"landing_domain"`
+	CreatedAt     time.Time `json:"created_at"`
+	Tags          []string  `json:"tags,omitempty"`
+	Severity      string    `json:"severity,omitempty"`
+}
+
+type linkData struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	PageURL   string    `json:"page_url"`
+	Domain    string    `json:"domain"`
+	CreatedAt time.Time `json:"created_at"`
+	Tags      []string  `json:"tags,omitempty"`
+	Severity  string    `json:"severity,omitempty"`
+}
+
+func (e DomainEvent) data() domainData {
+	return domainData{ID: e.ID, LandingDomain: e.LandingDomain, CreatedAt: e.CreatedAt, Tags: e.Tags, Severity: e.Severity}
+}
+
+func (e LinkEvent) data() linkData {
+	return linkData{
+		ID:        e.ID,
+		URL:       e.URL,
+		PageURL:   e.PageURL,
+		Domain:    e.Platform,
+		CreatedAt: e.CreatedAt,
+		Tags:      e.Tags,
+		Severity:  e.Severity,
+	}
+}
+
+// EventID — стабильный идентификатор события: тип сущности и её ID
+func (e DomainEvent) EventID() string {
+	return "domain:" + strconv.Itoa(e.ID)
+}
+
+func (e LinkEvent) EventID() string {
+	return "sociallink:" + strconv.Itoa(e.ID)
+}
+
+func (w *Webhook) NotifyDomains(ctx context.Context, events []DomainEvent) error {
+	payload := webhookPayload{Type: EventDomainDiscovered}
+	for _, d := range events {
+		payload.Events = append(payload.Events, webhookEvent{
+			ID:     d.EventID(),
+			Cursor: webhookCursor{CreatedAt: d.CreatedAt, ID: d.ID},
+			Data:   d.data(),
+		})
+	}
+	return w.post(ctx, payload)
+}
+
+func (w *Webhook) NotifyLinks(ctx context.Context, events []LinkEvent) error {
+	payload := webhookPayload{Type: EventSocialLinkDiscovered}
+	for _, l := range events {
+		payload.Events = append(payload.Events, webhookEvent{
+			ID:     l.EventID(),
+			Cursor: webhookCursor{CreatedAt: l.CreatedAt, ID: l.ID},
+			Data:   l.data(),
+		})
+	}
+	return w.post(ctx, payload)
+}
+
+func (w *Webhook) post(ctx context.Context, payload webhookPayload) error {
+	payload.SchemaVersion = WebhookSchemaVersion
+	payload.SentAt = time.Now().UTC()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	delivery := sha256.New()
+	for _, e := range payload.Events {
+		delivery.Write([]byte(e.ID))
+		delivery.Write([]byte{0})
+	}
+
+	ts := strconv.FormatInt(payload.SentAt.Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Watcher-Schema-Version", strconv.Itoa(WebhookSchemaVersion))
+	req.Header.Set("X-Watcher-Delivery", hex.EncodeToString(delivery.Sum(nil)))
+	req.Header.Set("X-Watcher-Timestamp", ts)
+	req.Header.Set("X-Watcher-Signature", "sha256="+signWebhook(w.Secret, ts, body))
+
+	_, err = doRequest(w.client, "webhook", req)
+	return err
+}
+
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var (
+	ErrWebhookSignature = errors.New("webhook signature mismatch")
+	ErrWebhookExpired   = errors.New("webhook timestamp outside tolerance")
+)
+
+// VerifyWebhookSignature проверяет заголовки X-Watcher-Timestamp и X-Watcher-Signature на стороне получателя.
+// tolerance ограничивает возраст запроса и защищает от повторного воспроизведения
+func VerifyWebhookSignature(secret, timestamp, signature string, body []byte, tolerance time.Duration) error {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp: %w", err)
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age < 0 {
+		age = -age
+	}
+	if age > tolerance {
+		return ErrWebhookExpired
+	}
+
+	expected := "sha256=" + signWebhook(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrWebhookSignature
+	}
+	return nil
+}
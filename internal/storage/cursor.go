@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/ent/consumercursor"
+)
+
+// таблицы, по которым двигаются курсоры
+const (
+	StreamDomains = "domains"
+	StreamLinks   = "social_links"
+)
+
+// имя потребителя по умолчанию (исторический единственный курсор)
+const DefaultConsumer = "default"
+
+// CursorStore хранит позиции курсоров по паре потребитель/таблица
+type CursorStore interface {
+	Load(ctx context.Context, consumer, stream string) (Cursor, error)
+	Save(ctx context.Context, consumer, stream string, cur Cursor) error
+	List(ctx context.Context) ([]ConsumerState, error)
+}
+
+// ConsumerState описывает сохранённый курсор одного потребителя
+type ConsumerState struct {
+	Consumer  string
+	Stream    string
+	Cursor    Cursor
+	UpdatedAt time.Time
+}
+
+func sortStates(states []ConsumerState) {
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Consumer != states[j].Consumer {
+			return states[i].Consumer < states[j].Consumer
+		}
+		return states[i].Stream < states[j].Stream
+	})
+}
+
+// FileCursorStore хранит курсоры в .json файлах в каталоге Dir
+type FileCursorStore struct {
+	Dir string
+}
+
+// старые имена файлов оставляем для потребителя по умолчанию, чтобы не потерять состояние
+var streamFilePrefix = map[string]string{
+	StreamDomains: "domain",
+	StreamLinks:   "link",
+}
+
+func (s *FileCursorStore) path(consumer, stream string) string {
+	prefix, ok := streamFilePrefix[stream]
+	if !ok {
+		prefix = stream
+	}
+	name := prefix + "_cursor.json"
+	if consumer != DefaultConsumer {
+		name = consumer + "_" + name
+	}
+	return filepath.Join(s.Dir, name)
+}
+
+func (s *FileCursorStore) Load(_ context.Context, consumer, stream string) (Cursor, error) {
+	data, err := os.ReadFile(s.path(consumer, stream))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Cursor{}, nil
+		}
+		return Cursor{}, err
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return Cursor{}, err
+	}
+	return cursor, nil
+}
+
+func (s *FileCursorStore) Save(_ context.Context, consumer, stream string, cur Cursor) error {
+	data, err := json.Marshal(cur)
+	if err != nil {
+		return err
+	}
+
+	// пишем во временный файл и переименовываем, чтобы не оставить битый курсор
+	path := s.path(consumer, stream)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *FileCursorStore) List(ctx context.Context) ([]ConsumerState, error) {
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []ConsumerState
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		for stream, prefix := range streamFilePrefix {
+			suffix := prefix + "_cursor.json"
+			name := e.Name()
+			if !strings.HasSuffix(name, suffix) {
+				continue
+			}
+			consumer := DefaultConsumer
+			if name != suffix {
+				consumer = strings.TrimSuffix(name, "_"+suffix)
+				if consumer == name {
+					continue
+				}
+			}
+			cur, err := s.Load(ctx, consumer, stream)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", name, err)
+			}
+			info, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+			states = append(states, ConsumerState{
+				Consumer:  consumer,
+				Stream:    stream,
+				Cursor:    cur,
+				UpdatedAt: info.ModTime(),
+			})
+		}
+	}
+	sortStates(states)
+	return states, nil
+}
+
+// PGCursorStore хранит курсоры в таблице consumer_cursors.
+// Если строки для курсора ещё нет, позиция берётся из Seed (например, старых .json файлов),
+// чтобы переход с файлового хранилища не вызывал повторных уведомлений
+type PGCursorStore struct {
+	client *ent.Client
+	Seed   CursorStore
+}
+
+func NewPGCursorStore(client *ent.Client) *PGCursorStore {
+	return &PGCursorStore{client: client}
+}
+
+func (s *PGCursorStore) Load(ctx context.Context, consumer, stream string) (Cursor, error) {
+	cc, err := s.client.ConsumerCursor.
+		Query().
+		Where(
+			consumercursor.ConsumerEQ(consumer),
+			consumercursor.StreamEQ(stream),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			if s.Seed != nil {
+				return s.Seed.Load(ctx, consumer, stream)
+			}
+			return Cursor{}, nil
+		}
+		return Cursor{}, err
+	}
+	return pgCursor(cc), nil
+}
+
+func (s *PGCursorStore) Save(ctx context.Context, consumer, stream string, cur Cursor) error {
+	return WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		return SaveCursorTx(ctx, tx, consumer, stream, cur)
+	})
+}
+
+func (s *PGCursorStore) List(ctx context.Context) ([]ConsumerState, error) {
+	rows, err := s.client.ConsumerCursor.Query().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]ConsumerState, 0, len(rows))
+	for _, r := range rows {
+		states = append(states, ConsumerState{
+			Consumer:  r.Consumer,
+			Stream:    r.Stream,
+			Cursor:    pgCursor(r),
+			UpdatedAt: r.UpdatedAt,
+		})
+	}
+	sortStates(states)
+	return states, nil
+}
+
+func pgCursor(cc *ent.ConsumerCursor) Cursor {
+	cur := Cursor{LastCreatedAt: cc.LastCreatedAt, LastID: cc.LastID, Recent: cc.Recent}
+	if cc.RecentSince != nil {
+		cur.RecentSince = *cc.RecentSince
+	}
+	return cur
+}
+
+// SaveCursorTx двигает курсор внутри уже открытой транзакции
+func SaveCursorTx(ctx context.Context, tx *ent.Tx, consumer, stream string, cur Cursor) error {
+	var since *time.Time
+	if !cur.RecentSince.IsZero() {
+		since = &cur.RecentSince
+	}
+	update := tx.ConsumerCursor.
+		Update().
+		Where(
+			consumercursor.ConsumerEQ(consumer),
+			consumercursor.StreamEQ(stream),
+		).
+		SetLastCreatedAt(cur.LastCreatedAt).
+		SetLastID(cur.LastID).
+		SetRecent(cur.Recent)
+	if since != nil {
+		update.SetRecentSince(*since)
+	} else {
+		update.ClearRecentSince()
+	}
+	n, err := update.Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	return tx.ConsumerCursor.
+		Create().
+		SetConsumer(consumer).
+		SetStream(stream).
+		SetLastCreatedAt(cur.LastCreatedAt).
+		SetLastID(cur.LastID).
+		SetRecent(cur.Recent).
+		SetNillableRecentSince(since).
+		Exec(ctx)
+}
+
+// WithTx выполняет fn в транзакции и откатывает её при ошибке
+func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w: rollback failed: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// NewCursorStore выбирает хранилище курсоров по значению CURSOR_STORE
+func NewCursorStore(kind string, client *ent.Client, dir string) (CursorStore, error) {
+	switch kind {
+	case "", "file":
+		return &FileCursorStore{Dir: dir}, nil
+	case "postgres":
+		store := NewPGCursorStore(client)
+		store.Seed = &FileCursorStore{Dir: dir}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown cursor store %q", kind)
+	}
+}
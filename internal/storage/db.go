@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -15,9 +16,87 @@ import (
 
 const PageSize = 1000
 
+// MaxRecent ограничивает Recent: курсор сохраняется после каждой пачки, и без предела его размер
+// рос бы пропорционально потоку вставок, умноженному на окно look-back
+const MaxRecent = 50000
+
 type Cursor struct {
 	LastCreatedAt time.Time `json:"last_created_at"`
 	LastID        int       `json:"last_id"`
+	// уже обработанные строки внутри окна look-back (id -> created_at), нужны для дедупликации;
+	// nil означает, что окно ещё не заполнялось (пустой map сохраняется как {})
+	Recent map[int]time.Time `json:"recent"`
+	// начало окна look-back, если Recent обрезали до MaxRecent: строки до него уже забыты,
+	// и искать опоздавшие раньше нельзя, иначе они разошлются повторно
+	RecentSince time.Time `json:"recent_since,omitzero"`
+}
+
+// ReplayCursor — курсор "с самого начала таблицы". В отличие от нулевого курсора нового потребителя,
+// с которого первый проход молча догоняет таблицу, с него агент разошлёт все строки заново
+func ReplayCursor() Cursor {
+	return Cursor{LastCreatedAt: time.Unix(0, 0).UTC()}
+}
+
+// IsZero сообщает, что курсор никогда не сохранялся: потребитель новый
+func (c Cursor) IsZero() bool {
+	return c.LastCreatedAt.IsZero() && c.LastID == 0
+}
+
+// Remember отмечает строку как обработанную
+func (c *Cursor) Remember(id int, createdAt time.Time) {
+	if c.Recent == nil {
+		c.Recent = make(map[int]time.Time)
+	}
+	c.Recent[id] = createdAt
+}
+
+// Seen сообщает, обрабатывалась ли строка внутри окна
+func (c *Cursor) Seen(id int) bool {
+	_, ok := c.Recent[id]
+	return ok
+}
+
+// Prune выкидывает строки, вышедшие за окно look-back
+func (c *Cursor) Prune(window time.Duration) {
+	edge := c.LastCreatedAt.Add(-window)
+	for id, createdAt := range c.Recent {
+		if createdAt.Before(edge) {
+			delete(c.Recent, id)
+		}
+	}
+	if c.RecentSince.Before(edge) {
+		c.RecentSince = time.Time{}
+	}
+}
+
+// Trim оставляет в Recent не больше max самых свежих строк и сдвигает RecentSince на самую старую
+// из оставшихся, сужая окно look-back. Возвращает true, если пришлось обрезать
+func (c *Cursor) Trim(max int) bool {
+	if len(c.Recent) <= max {
+		return false
+	}
+	times := make([]time.Time, 0, len(c.Recent))
+	for _, createdAt := range c.Recent {
+		times = append(times, createdAt)
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	edge := times[len(times)-max]
+	for id, createdAt := range c.Recent {
+		if createdAt.Before(edge) {
+			delete(c.Recent, id)
+		}
+	}
+	c.RecentSince = edge
+	return true
+}
+
+// LateSince — начало окна look-back позади курсора с учётом RecentSince
+func (c Cursor) LateSince(window time.Duration) time.Time {
+	since := c.LastCreatedAt.Add(-window)
+	if c.RecentSince.After(since) {
+		return c.RecentSince
+	}
+	return since
 }
 
 type DatabaseConfig struct {
@@ -55,37 +134,120 @@ func (cfg *DatabaseConfig) DSN() string {
 	)
 }
 
+// CheckNewSocialLinks возвращает следующую страницу ссылок после курсора.
+// Порядок (created_at, id) и Limit обязательны и для нулевого курсора: первая синхронизация
+// идёт страницами по всей таблице, а последняя строка страницы — корректная новая позиция
 func CheckNewSocialLinks(ctx context.Context, client *ent.Client, cur Cursor) ([]*ent.SocialLink, error) {
-	var q *ent.SocialLinkQuery
-	if cur.LastCreatedAt.IsZero() && cur.LastID == 0 {
-		q = client.SocialLink.Query()
-	} else {
-		q = client.SocialLink.
-			Query().
-			Where(
-				sociallink.Or(
-					sociallink.CreatedAtGT(cur.LastCreatedAt),
-					sociallink.And(
-						sociallink.CreatedAtEQ(cur.LastCreatedAt),
-						sociallink.IDGT(cur.LastID),
-					),
+	q := client.SocialLink.Query()
+	if !cur.LastCreatedAt.IsZero() || cur.LastID != 0 {
+		q = q.Where(
+			sociallink.Or(
+				sociallink.CreatedAtGT(cur.LastCreatedAt),
+				sociallink.And(
+					sociallink.CreatedAtEQ(cur.LastCreatedAt),
+					sociallink.IDGT(cur.LastID),
 				),
-			).
-			Order(ent.Asc(sociallink.FieldCreatedAt),
-				ent.Asc(sociallink.FieldID),
-			).
-			Limit(PageSize)
+			),
+		)
 	}
 
-	return q.All(ctx)
+	return q.
+		Order(
+			ent.Asc(sociallink.FieldCreatedAt),
+			ent.Asc(sociallink.FieldID),
+		).
+		Limit(PageSize).
+		All(ctx)
 }
 
+// CheckNewDomains возвращает следующую страницу доменов после курсора, см. CheckNewSocialLinks
 func CheckNewDomains(ctx context.Context, client *ent.Client, cur Cursor) ([]*ent.Domain, error) {
-	var q *ent.DomainQuery
-	if cur.LastCreatedAt.IsZero() && cur.LastID == 0 {
-		q = client.Domain.Query()
-	} else {
-		q = client.Domain.
+	q := client.Domain.Query()
+	if !cur.LastCreatedAt.IsZero() || cur.LastID != 0 {
+		q = q.Where(
+			domain.Or(
+				domain.CreatedAtGT(cur.LastCreatedAt),
+				domain.And(
+					domain.CreatedAtEQ(cur.LastCreatedAt),
+					domain.IDGT(cur.LastID),
+				),
+			),
+		)
+	}
+
+	return q.
+		Order(
+			ent.Asc(domain.FieldCreatedAt),
+			ent.Asc(domain.FieldID),
+		).
+		Limit(PageSize).
+		All(ctx)
+}
+
+// CheckLateSocialLinks возвращает страницу ссылок окна look-back позади курсора cur, идущих после after:
+// среди них могут быть вставленные с created_at "в прошлом" (рассинхрон часов парсеров, долгие транзакции).
+// Окно обходится страницами по PageSize с after = последней строке предыдущей страницы, начиная
+// с Cursor{LastCreatedAt: cur.LateSince(window)}; уже обработанные строки отсеивает вызывающий по cur.Seen
+func CheckLateSocialLinks(ctx context.Context, client *ent.Client, cur, after Cursor) ([]*ent.SocialLink, error) {
+	return client.SocialLink.
+		Query().
+		Where(
+			sociallink.Or(
+				sociallink.CreatedAtGT(after.LastCreatedAt),
+				sociallink.And(
+					sociallink.CreatedAtEQ(after.LastCreatedAt),
+					sociallink.IDGT(after.LastID),
+				),
+			),
+			sociallink.Or(
+				sociallink.CreatedAtLT(cur.LastCreatedAt),
+				sociallink.And(
+					sociallink.CreatedAtEQ(cur.LastCreatedAt),
+					sociallink.IDLTE(cur.LastID),
+				),
+			),
+		).
+		Order(
+			ent.Asc(sociallink.FieldCreatedAt),
+			ent.Asc(sociallink.FieldID),
+		).
+		Limit(PageSize).
+		All(ctx)
+}
+
+// CheckLateDomains — то же для доменов, см. CheckLateSocialLinks
+func CheckLateDomains(ctx context.Context, client *ent.Client, cur, after Cursor) ([]*ent.Domain, error) {
+	return client.Domain.
+		Query().
+		Where(
+			domain.Or(
+				domain.CreatedAtGT(after.LastCreatedAt),
+				domain.And(
+					domain.CreatedAtEQ(after.LastCreatedAt),
+					domain.IDGT(after.LastID),
+				),
+			),
+			domain.Or(
+				domain.CreatedAtLT(cur.LastCreatedAt),
+				domain.And(
+					domain.CreatedAtEQ(cur.LastCreatedAt),
+					domain.IDLTE(cur.LastID),
+				),
+			),
+		).
+		Order(
+			ent.Asc(domain.FieldCreatedAt),
+			ent.Asc(domain.FieldID),
+		).
+		Limit(PageSize).
+		All(ctx)
+}
+
+// CountPending считает строки таблицы stream, которые курсор ещё не прошёл
+func CountPending(ctx context.Context, client *ent.Client, stream string, cur Cursor) (int, error) {
+	switch stream {
+	case StreamDomains:
+		return client.Domain.
 			Query().
 			Where(
 				domain.Or(
@@ -96,11 +258,41 @@ func CheckNewDomains(ctx context.Context, client *ent.Client, cur Cursor) ([]*en
 					),
 				),
 			).
-			Order(
-				ent.Asc(domain.FieldCreatedAt),
-				ent.Asc(domain.FieldID),
+			Count(ctx)
+	case StreamLinks:
+		return client.SocialLink.
+			Query().
+			Where(
+				sociallink.Or(
+					sociallink.CreatedAtGT(cur.LastCreatedAt),
+					sociallink.And(
+						sociallink.CreatedAtEQ(cur.LastCreatedAt),
+						sociallink.IDGT(cur.LastID),
+					),
+				),
 			).
-			Limit(PageSize)
+			Count(ctx)
+	default:
+		return 0, fmt.Errorf("unknown stream %q", stream)
+	}
+}
+
+// CursorAtID возвращает курсор, стоящий сразу после строки с указанным ID
+func CursorAtID(ctx context.Context, client *ent.Client, stream string, id int) (Cursor, error) {
+	switch stream {
+	case StreamDomains:
+		d, err := client.Domain.Get(ctx, id)
+		if err != nil {
+			return Cursor{}, err
+		}
+		return Cursor{LastCreatedAt: d.CreatedAt, LastID: d.ID}, nil
+	case StreamLinks:
+		l, err := client.SocialLink.Get(ctx, id)
+		if err != nil {
+			return Cursor{}, err
+		}
+		return Cursor{LastCreatedAt: l.CreatedAt, LastID: l.ID}, nil
+	default:
+		return Cursor{}, fmt.Errorf("unknown stream %q", stream)
 	}
-	return q.All(ctx)
 }
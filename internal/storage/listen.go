@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// каналы NOTIFY, в которые пишут триггеры на вставку
+var notifyChannels = map[string]string{
+	StreamDomains: "domains_inserted",
+	StreamLinks:   "social_links_inserted",
+}
+
+// триггер уровня statement: одна вставка пачкой даёт одно уведомление
+const notifyFunctionSQL = `
+CREATE OR REPLACE FUNCTION agent_notify_insert() RETURNS trigger AS $$
+BEGIN
+	PERFORM pg_notify(TG_TABLE_NAME || '_inserted', '');
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`
+
+// InstallNotifyTriggers ставит (или переставляет) триггеры на вставку в domains и social_links
+func InstallNotifyTriggers(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, notifyFunctionSQL); err != nil {
+		return fmt.Errorf("create notify function: %w", err)
+	}
+	for _, table := range []string{StreamDomains, StreamLinks} {
+		stmt := fmt.Sprintf(`
+DROP TRIGGER IF EXISTS agent_notify_insert ON %[1]s;
+CREATE TRIGGER agent_notify_insert AFTER INSERT ON %[1]s
+	FOR EACH STATEMENT EXECUTE PROCEDURE agent_notify_insert()`, pq.QuoteIdentifier(table))
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create notify trigger on %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// Listener будит циклы сканирования по NOTIFY от postgres.
+// Пока соединение потеряно, будит их каждые pollInterval, а после переподключения
+// будит сразу, чтобы дочитать строки, вставленные без уведомления
+type Listener struct {
+	l            *pq.Listener
+	pollInterval time.Duration
+	wake         map[string]chan struct{}
+	state        chan pq.ListenerEventType
+}
+
+func NewListener(dsn string, pollInterval time.Duration) (*Listener, error) {
+	ln := &Listener{
+		pollInterval: pollInterval,
+		wake:         make(map[string]chan struct{}),
+		state:        make(chan pq.ListenerEventType, 8),
+	}
+
+	ln.l = pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error().Err(err).Msg("listener connection event")
+		}
+		select {
+		case ln.state <- ev:
+		default:
+		}
+	})
+
+	for stream, channel := range notifyChannels {
+		if err := ln.l.Listen(channel); err != nil {
+			ln.l.Close()
+			return nil, fmt.Errorf("listen %s: %w", channel, err)
+		}
+		ln.wake[stream] = make(chan struct{}, 1)
+	}
+	return ln, nil
+}
+
+// Wake возвращает канал пробуждения для таблицы stream
+func (ln *Listener) Wake(stream string) <-chan struct{} {
+	return ln.wake[stream]
+}
+
+// Run разбирает уведомления до отмены ctx
+func (ln *Listener) Run(ctx context.Context) {
+	defer ln.l.Close()
+
+	// тикер нужен только пока соединение потеряно
+	var poll <-chan time.Time
+	var t *time.Ticker
+	defer func() {
+		if t != nil {
+			t.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-ln.l.Notify:
+			// nil приходит после переподключения: уведомления могли потеряться
+			if n == nil {
+				ln.wakeAll()
+				continue
+			}
+			for stream, channel := range notifyChannels {
+				if n.Channel == channel {
+					ln.signal(stream)
+				}
+			}
+		case ev := <-ln.state:
+			switch ev {
+			case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+				if t == nil {
+					log.Warn().Dur("interval", ln.pollInterval).Msg("listener disconnected, falling back to polling")
+					t = time.NewTicker(ln.pollInterval)
+					poll = t.C
+				}
+			case pq.ListenerEventReconnected:
+				if t != nil {
+					log.Info().Msg("listener reconnected")
+					t.Stop()
+					t, poll = nil, nil
+				}
+				ln.wakeAll()
+			}
+		case <-poll:
+			ln.wakeAll()
+		}
+	}
+}
+
+func (ln *Listener) wakeAll() {
+	for stream := range ln.wake {
+		ln.signal(stream)
+	}
+}
+
+// неблокирующая отправка: несколько уведомлений подряд схлопываются в одно сканирование
+func (ln *Listener) signal(stream string) {
+	select {
+	case ln.wake[stream] <- struct{}{}:
+	default:
+	}
+}
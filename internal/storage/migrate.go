@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql/schema"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/ent/migrate"
+)
+
+// таблицы, которыми владеет агент; domains и social_links создаёт парсер, их не трогаем
+var agentTables = []*schema.Table{
+	migrate.ConsumerCursorsTable,
+	migrate.OutboxTable,
+}
+
+func MigrateAgentTables(ctx context.Context, client *ent.Client) error {
+	return migrate.Create(ctx, client.Schema, agentTables)
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeshi09/go_web_parser_agent/ent"
+	"github.com/zeshi09/go_web_parser_agent/ent/outbox"
+)
+
+// OutboxEntry — одна строка, которую нужно доставить в sink
+type OutboxEntry struct {
+	Kind     outbox.Kind
+	EntityID int
+	Key      string
+	Payload  string
+}
+
+// EnqueueTx кладёт записи в outbox для каждого sink внутри транзакции tx.
+// Записи, ключ которых уже есть у потребителя в этом sink, пропускаются,
+// поэтому повторное сканирование тех же строк не даёт повторной доставки
+func EnqueueTx(ctx context.Context, tx *ent.Tx, consumer string, sinks []string, entries []OutboxEntry) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		keys = append(keys, e.Key)
+	}
+
+	enqueued := 0
+	for _, sink := range sinks {
+		existing, err := tx.Outbox.
+			Query().
+			Where(
+				outbox.ConsumerEQ(consumer),
+				outbox.SinkEQ(sink),
+				outbox.KeyIn(keys...),
+			).
+			Select(outbox.FieldKey).
+			Strings(ctx)
+		if err != nil {
+			return 0, err
+		}
+		seen := make(map[string]bool, len(existing))
+		for _, k := range existing {
+			seen[k] = true
+		}
+
+		var creates []*ent.OutboxCreate
+		for _, e := range entries {
+			if seen[e.Key] {
+				continue
+			}
+			seen[e.Key] = true
+			creates = append(creates, tx.Outbox.
+				Create().
+				SetConsumer(consumer).
+				SetSink(sink).
+				SetKind(e.Kind).
+				SetEntityID(e.EntityID).
+				SetKey(e.Key).
+				SetPayload(e.Payload))
+		}
+		if len(creates) == 0 {
+			continue
+		}
+		if err := tx.Outbox.CreateBulk(creates...).Exec(ctx); err != nil {
+			return 0, err
+		}
+		enqueued += len(creates)
+	}
+	return enqueued, nil
+}
+
+// DueOutbox возвращает ожидающие записи, время отправки которых уже наступило
+func DueOutbox(ctx context.Context, client *ent.Client, consumer string, limit int) ([]*ent.Outbox, error) {
+	return client.Outbox.
+		Query().
+		Where(
+			outbox.ConsumerEQ(consumer),
+			outbox.StatusEQ(outbox.StatusPending),
+			outbox.NextAttemptAtLTE(time.Now()),
+		).
+		Order(ent.Asc(outbox.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+func MarkDelivered(ctx context.Context, client *ent.Client, ids []int) error {
+	return client.Outbox.
+		Update().
+		Where(outbox.IDIn(ids...)).
+		SetStatus(outbox.StatusDelivered).
+		SetDeliveredAt(time.Now()).
+		Exec(ctx)
+}
+
+// MarkRetry откладывает запись до next; после исчерпания попыток переводит её в failed
+func MarkRetry(ctx context.Context, client *ent.Client, row *ent.Outbox, next time.Time, failed bool, cause error) error {
+	upd := client.Outbox.
+		UpdateOneID(row.ID).
+		AddAttempts(1).
+		SetNextAttemptAt(next).
+		SetLastError(cause.Error())
+	if failed {
+		upd = upd.SetStatus(outbox.StatusFailed)
+	}
+	return upd.Exec(ctx)
+}
+
+// PurgeDelivered удаляет доставленные записи старше before
+func PurgeDelivered(ctx context.Context, client *ent.Client, before time.Time) (int, error) {
+	return client.Outbox.
+		Delete().
+		Where(
+			outbox.StatusEQ(outbox.StatusDelivered),
+			outbox.DeliveredAtLT(before),
+		).
+		Exec(ctx)
+}
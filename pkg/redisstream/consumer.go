@@ -0,0 +1,152 @@
+// Package redisstream читает события агента из Redis Streams через consumer group'ы.
+// Каждая запись стрима содержит поля FieldID, FieldType и FieldPayload (JSON события, как в webhook-схеме v1)
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// поля записи в стриме
+const (
+	FieldID      = "id"
+	FieldType    = "type"
+	FieldPayload = "payload"
+)
+
+// Message — одна запись стрима
+type Message struct {
+	StreamID string // id записи в redis (1700000000000-0)
+	EventID  string // стабильный id события: domain:115, sociallink:42
+	Type     string // domain.discovered или sociallink.discovered
+	Payload  []byte
+}
+
+// Handler обрабатывает сообщение; при ошибке сообщение не подтверждается
+// и будет перезахвачено после MinIdle
+type Handler func(ctx context.Context, msg Message) error
+
+// Consumer читает стрим в составе consumer group и подтверждает обработанные сообщения (XACK)
+type Consumer struct {
+	Client *redis.Client
+	Stream string
+	Group  string
+	Name   string
+
+	Count   int64         // сколько сообщений читать за раз
+	Block   time.Duration // сколько ждать новых сообщений в XREADGROUP
+	MinIdle time.Duration // через сколько чужие/упавшие pending сообщения перезахватываются
+}
+
+func NewConsumer(client *redis.Client, stream, group, name string) *Consumer {
+	return &Consumer{
+		Client:  client,
+		Stream:  stream,
+		Group:   group,
+		Name:    name,
+		Count:   100,
+		Block:   5 * time.Second,
+		MinIdle: time.Minute,
+	}
+}
+
+// EnsureGroup создаёт группу (и стрим, если его ещё нет); существующая группа не ошибка
+func (c *Consumer) EnsureGroup(ctx context.Context, start string) error {
+	if start == "" {
+		start = "$"
+	}
+	err := c.Client.XGroupCreateMkStream(ctx, c.Stream, c.Group, start).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// Run обрабатывает сообщения до отмены ctx: сначала перезахватывает зависшие pending,
+// затем читает новые
+func (c *Consumer) Run(ctx context.Context, h Handler) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+
+		if err := c.reclaim(ctx, h); err != nil {
+			return err
+		}
+
+		streams, err := c.Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.Group,
+			Consumer: c.Name,
+			Streams:  []string{c.Stream, ">"},
+			Count:    c.Count,
+			Block:    c.Block,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, s := range streams {
+			if err := c.handle(ctx, h, s.Messages); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reclaim забирает сообщения, которые провисели в pending дольше MinIdle
+func (c *Consumer) reclaim(ctx context.Context, h Handler) error {
+	start := "0-0"
+	for {
+		msgs, next, err := c.Client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   c.Stream,
+			Group:    c.Group,
+			Consumer: c.Name,
+			MinIdle:  c.MinIdle,
+			Start:    start,
+			Count:    c.Count,
+		}).Result()
+		if err != nil {
+			return err
+		}
+		if err := c.handle(ctx, h, msgs); err != nil {
+			return err
+		}
+		if next == "0-0" || len(msgs) == 0 {
+			return nil
+		}
+		start = next
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, h Handler, msgs []redis.XMessage) error {
+	for _, m := range msgs {
+		msg := Message{StreamID: m.ID}
+		if v, ok := m.Values[FieldID].(string); ok {
+			msg.EventID = v
+		}
+		if v, ok := m.Values[FieldType].(string); ok {
+			msg.Type = v
+		}
+		if v, ok := m.Values[FieldPayload].(string); ok {
+			msg.Payload = []byte(v)
+		}
+
+		// ошибку обработчика не считаем фатальной: сообщение останется в pending
+		if err := h(ctx, msg); err != nil {
+			continue
+		}
+		if err := c.Client.XAck(ctx, c.Stream, c.Group, m.ID).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}